@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// badgerGCDiscardRatio is the discard ratio for value log garbage collection on Close
+// A value log file is rewritten when at least this fraction of it can be discarded
+const badgerGCDiscardRatio = 0.5
+
+// BadgerCache implements the BatchCacher interface with an embedded cache backed by Badger
+// Badger's LSM tree is optimized for write throughput, and entries expire natively with their TTL.
+// Values are encoded with a Coder. Data persists across restarts unless InMemory is set
+type BadgerCache[V any] struct {
+	db       *badger.DB
+	coder    Coder[V]
+	inMemory bool
+}
+
+// BadgerCacheConfig holds configuration for BadgerCache
+type BadgerCacheConfig struct {
+	// Path is the directory holding the database files, created if it does not exist.
+	// Ignored when InMemory is true.
+	Path string
+
+	// InMemory keeps all data in memory without writing to disk
+	InMemory bool
+
+	// Logger receives Badger's internal logs (optional).
+	// If nil, Badger's logging is disabled.
+	Logger badger.Logger
+}
+
+// DefaultBadgerCacheConfig returns a default configuration
+func DefaultBadgerCacheConfig() *BadgerCacheConfig {
+	return &BadgerCacheConfig{
+		Path:     "cache-badger",
+		InMemory: false,
+		Logger:   nil,
+	}
+}
+
+// NewBadgerCache opens (or creates) the Badger database
+// If coder is nil, JSONCoder is used
+func NewBadgerCache[V any](config *BadgerCacheConfig, coder Coder[V]) (*BadgerCache[V], error) {
+	if config == nil {
+		config = DefaultBadgerCacheConfig()
+	}
+	if coder == nil {
+		coder = NewJSONCoder[V]()
+	}
+
+	path := config.Path
+	if config.InMemory {
+		path = ""
+	}
+	opts := badger.DefaultOptions(path).
+		WithInMemory(config.InMemory).
+		WithLogger(config.Logger)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BadgerCache[V]{
+		db:       db,
+		coder:    coder,
+		inMemory: config.InMemory,
+	}, nil
+}
+
+// Get retrieves a value from the cache
+func (b *BadgerCache[V]) Get(ctx context.Context, key string) (V, error) {
+	var zero V
+	var data []byte
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		data, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return zero, ErrCacheMiss
+		}
+		return zero, err
+	}
+	return b.coder.Decode(data)
+}
+
+// Set stores a value in the cache with a TTL
+// Badger expires entries with second precision
+func (b *BadgerCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	data, err := b.coder.Encode(value)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.SetEntry(badgerEntry(key, data, ttl))
+	})
+}
+
+// GetTTL returns the remaining time-to-live of a key
+// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+func (b *BadgerCache[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	var expiresAt uint64
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		expiresAt = item.ExpiresAt()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return 0, ErrCacheMiss
+		}
+		return 0, err
+	}
+	if expiresAt == 0 {
+		return 0, ErrNoExpiry
+	}
+	return time.Until(time.Unix(int64(expiresAt), 0)), nil
+}
+
+// Delete removes a value from the cache
+// Returns ErrCacheMiss if the key is not found
+func (b *BadgerCache[V]) Delete(ctx context.Context, key string) error {
+	err := b.db.Update(func(txn *badger.Txn) error {
+		if _, err := txn.Get([]byte(key)); err != nil {
+			return err
+		}
+		return txn.Delete([]byte(key))
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return ErrCacheMiss
+	}
+	return err
+}
+
+// BatchGet retrieves multiple values from the cache in a single read transaction
+// Returns a map of key-value pairs for found keys
+// Missing keys and keys that fail to decode are not included in the returned map
+func (b *BadgerCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	found := make(map[string][]byte, len(keys))
+	err := b.db.View(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			item, err := txn.Get([]byte(key))
+			if err != nil {
+				if errors.Is(err, badger.ErrKeyNotFound) {
+					continue
+				}
+				return err
+			}
+			data, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			found[key] = data
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]V, len(found))
+	for key, data := range found {
+		value, err := b.coder.Decode(data)
+		if err != nil {
+			continue
+		}
+		results[key] = value
+	}
+	return results, nil
+}
+
+// BatchSet stores multiple values in the cache with a TTL using a write batch
+// The batch is split into multiple transactions when it exceeds Badger's transaction size limit,
+// so it is not atomic. All items share the same TTL
+func (b *BadgerCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+	wb := b.db.NewWriteBatch()
+	defer wb.Cancel()
+	for key, value := range items {
+		data, err := b.coder.Encode(value)
+		if err != nil {
+			return err
+		}
+		if err := wb.SetEntry(badgerEntry(key, data, ttl)); err != nil {
+			return err
+		}
+	}
+	return wb.Flush()
+}
+
+// BatchDelete removes multiple values from the cache using a write batch
+// Missing keys are ignored
+func (b *BadgerCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	wb := b.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, key := range keys {
+		if err := wb.Delete([]byte(key)); err != nil {
+			return err
+		}
+	}
+	return wb.Flush()
+}
+
+// Clear removes all items from the cache
+func (b *BadgerCache[V]) Clear(ctx context.Context) error {
+	return b.db.DropAll()
+}
+
+// Close runs value log garbage collection until there is nothing left to rewrite and closes the database
+// Garbage collection is skipped in memory-only mode
+func (b *BadgerCache[V]) Close() error {
+	if !b.inMemory {
+		for {
+			if err := b.db.RunValueLogGC(badgerGCDiscardRatio); err != nil {
+				if !errors.Is(err, badger.ErrNoRewrite) {
+					return errors.Join(err, b.db.Close())
+				}
+				break
+			}
+		}
+	}
+	return b.db.Close()
+}
+
+// badgerEntry creates an entry expiring after ttl, or never if ttl is zero or less
+func badgerEntry(key string, data []byte, ttl time.Duration) *badger.Entry {
+	entry := badger.NewEntry([]byte(key), data)
+	if ttl > 0 {
+		entry = entry.WithTTL(ttl)
+	}
+	return entry
+}
@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// BatchSizePolicy controls how batch operations handle requests with more keys than the configured maximum
+type BatchSizePolicy int
+
+const (
+	// BatchSplit executes an oversized request as sequential sub-batches of the maximum size and merges the results
+	BatchSplit BatchSizePolicy = iota
+	// BatchReject rejects an oversized request with ErrBatchTooLarge without executing it
+	BatchReject
+)
+
+// BatchItemPolicy controls how batch writes handle individual items that cannot be written
+type BatchItemPolicy int
+
+const (
+	// BatchRejectAll rejects the whole batch when any item cannot be written
+	BatchRejectAll BatchItemPolicy = iota
+	// BatchSkipInvalid skips items that cannot be written, writes the rest,
+	// and returns the per-item failures joined into a single error
+	BatchSkipInvalid
+)
+
+// BatchResult holds the outcome of a batch read
+type BatchResult[V any] struct {
+	// Values maps found keys to their values
+	Values map[string]V
+
+	// Missed lists the requested keys that were not found, in the caller's input order
+	Missed []string
+
+	// Errors maps keys that could not be read (e.g., decode errors) to their error
+	// These keys are in neither Values nor Missed
+	Errors map[string]error
+}
+
+// BatchResultGetter defines the interface for cache implementations that can report misses and per-key errors of a batch read
+type BatchResultGetter[V any] interface {
+	// BatchGetResult retrieves multiple values from cache and reports which keys were missed
+	BatchGetResult(ctx context.Context, keys []string) (BatchResult[V], error)
+}
+
+// BatchGetDetailed retrieves multiple values from a cache and reports which keys were missed
+// Uses BatchGetResult when the cache implements BatchResultGetter, otherwise BatchGet when it
+// implements BatchCacher, and falls back to sequential Get calls
+func BatchGetDetailed[V any](ctx context.Context, c Cacher[V], keys []string) (BatchResult[V], error) {
+	if rg, ok := c.(BatchResultGetter[V]); ok {
+		return rg.BatchGetResult(ctx, keys)
+	}
+	found, err := batchGet(ctx, c, keys)
+	if err != nil {
+		return BatchResult[V]{}, err
+	}
+	return BatchResult[V]{
+		Values: found,
+		Missed: FilterMissingKeys(keys, found),
+		Errors: make(map[string]error),
+	}, nil
+}
+
+// FilterMissingKeys returns keys that are not present in the foundKeys map
+// The order of keys is preserved
+func FilterMissingKeys[V any](keys []string, foundKeys map[string]V) []string {
+	missing := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, found := foundKeys[key]; !found {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// dedupKeys returns keys without duplicates, keeping the first occurrence of each key in order
+// keys is returned as-is when it has no duplicates
+func dedupKeys(keys []string) []string {
+	seen := make(map[string]struct{}, len(keys))
+	for i, key := range keys {
+		if _, dup := seen[key]; dup {
+			unique := make([]string, i, len(keys))
+			copy(unique, keys[:i])
+			for _, key := range keys[i+1:] {
+				if _, dup := seen[key]; !dup {
+					seen[key] = struct{}{}
+					unique = append(unique, key)
+				}
+			}
+			return unique
+		}
+		seen[key] = struct{}{}
+	}
+	return keys
+}
+
+// batchGet retrieves multiple values from a cache
+// Uses BatchGet when the cache implements BatchCacher, otherwise falls back to sequential Get calls
+// Missing keys are simply not included in the returned map
+func batchGet[V any](ctx context.Context, c Cacher[V], keys []string) (map[string]V, error) {
+	if bc, ok := c.(BatchCacher[V]); ok {
+		return bc.BatchGet(ctx, keys)
+	}
+	results := make(map[string]V, len(keys))
+	for _, key := range keys {
+		value, err := c.Get(ctx, key)
+		if err != nil {
+			if errors.Is(err, ErrCacheMiss) {
+				continue
+			}
+			return results, err
+		}
+		results[key] = value
+	}
+	return results, nil
+}
+
+// batchSet stores multiple values in a cache with a TTL
+// Uses BatchSet when the cache implements BatchCacher, otherwise falls back to sequential Set calls
+// Returns ErrSetDropped if any write was dropped; the remaining writes are still attempted
+func batchSet[V any](ctx context.Context, c Cacher[V], items map[string]V, ttl time.Duration) error {
+	if bc, ok := c.(BatchCacher[V]); ok {
+		return bc.BatchSet(ctx, items, ttl)
+	}
+	dropped := false
+	for key, value := range items {
+		if err := c.Set(ctx, key, value, ttl); err != nil {
+			if !errors.Is(err, ErrSetDropped) {
+				return err
+			}
+			dropped = true
+		}
+	}
+	if dropped {
+		return ErrSetDropped
+	}
+	return nil
+}
+
+// batchDelete removes multiple values from a cache
+// Uses BatchDelete when the cache implements BatchCacher, otherwise falls back to sequential Delete calls
+// Missing keys are ignored
+func batchDelete[V any](ctx context.Context, c Cacher[V], keys []string) error {
+	if bc, ok := c.(BatchCacher[V]); ok {
+		return bc.BatchDelete(ctx, keys)
+	}
+	for _, key := range keys {
+		if err := c.Delete(ctx, key); err != nil && !errors.Is(err, ErrCacheMiss) {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultWarmChunkSize is the default number of items written per BatchSet call when warming a cache
+const defaultWarmChunkSize = 500
+
+// warmCache writes items to a cache in chunks of at most chunkSize items
+// If chunkSize is not positive, defaultWarmChunkSize is used. ErrSetDropped is ignored
+func warmCache[V any](ctx context.Context, c Cacher[V], items map[string]V, ttl time.Duration, chunkSize int) error {
+	if chunkSize <= 0 {
+		chunkSize = defaultWarmChunkSize
+	}
+	chunk := make(map[string]V, min(chunkSize, len(items)))
+	flush := func() error {
+		if err := batchSet(ctx, c, chunk, ttl); err != nil && !errors.Is(err, ErrSetDropped) {
+			return err
+		}
+		clear(chunk)
+		return nil
+	}
+	for key, value := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		chunk[key] = value
+		if len(chunk) >= chunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(chunk) > 0 {
+		return flush()
+	}
+	return nil
+}
@@ -2,24 +2,120 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // BatchComputeFunc is a function that computes multiple values when cache misses occur
 // It receives a slice of keys and returns a map of key-value pairs
 type BatchComputeFunc[V any] func(ctx context.Context, keys []string) (map[string]V, error)
 
+// BatchComputeErrorPolicy controls what BatchTieredCache does with the values batchComputeFn returns alongside an error
+type BatchComputeErrorPolicy int
+
+const (
+	// BatchComputeAllOrNothing discards every computed value when batchComputeFn returns an error,
+	// so nothing is cached and the returned map only holds values found in the cache tiers
+	BatchComputeAllOrNothing BatchComputeErrorPolicy = iota
+	// BatchComputePartial caches and returns the values batchComputeFn returned even alongside an error
+	// (with chunking, the values of every chunk that returned any), so keys resolved before the failure
+	// are not recomputed on the next call
+	BatchComputePartial
+)
+
 // BatchTieredCache implements multi-key cache operations with tiered caching strategy
 // Strategy: caches[0] (L1) → caches[1] (L2) → ... → caches[n] (Ln)
 // Optimized for batch operations where the compute function can fetch multiple keys efficiently
+// Concurrent BatchGet calls coalesce per key, so a missing key is computed once even when it is
+// requested by overlapping batches. Coalescing always shares the ctx, like CoalesceShared in TieredCache:
+// a key is computed with the ctx of the BatchGet call that claimed it, and the calls waiting for it
+// share its result. There is no per-caller mode
 type BatchTieredCache[V any] struct {
-	caches []BatchCacher[V]
+	caches        []BatchCacher[V]
+	chunkSize     int
+	concurrency   int
+	backfill      []BackfillPolicy
+	onBackfillErr func(key string, err error)
+	warmChunkSize int
+	errorPolicy   BatchComputeErrorPolicy
+
+	mu       sync.Mutex
+	inflight map[string]*batchCall[V]
+}
+
+// batchCall is an in-flight compute of a single key, shared by concurrent BatchGet calls
+// value, found and err are written before done is closed
+type batchCall[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+// BatchTieredCacheConfig holds configuration for BatchTieredCache
+type BatchTieredCacheConfig struct {
+	// ChunkSize splits the keys missing from every tier into chunks of at most this many keys
+	// when positive, and batchComputeFn is called once per chunk instead of once for all keys.
+	// Useful when the compute backend paginates or rate-limits.
+	ChunkSize int
+
+	// Concurrency is the maximum number of chunks computed in parallel (default: 1).
+	// Only used when ChunkSize is positive.
+	Concurrency int
+
+	// BackfillPolicies holds an optional BackfillPolicy per tier, indexed like the tiers (0 = L1).
+	// Keys found in a lower tier are backfilled into each tier above it unless its policy
+	// returns false for the key. Tiers without a policy (nil or beyond the slice) are always backfilled.
+	BackfillPolicies []BackfillPolicy
+
+	// OnBackfillError is called for each key whose backfill into an upper tier failed or was dropped
+	// (ErrSetDropped) (optional). A failed BatchSet reports its error for every key of the batch.
+	// Backfill stays best-effort and BatchGet still returns the values.
+	OnBackfillError func(key string, err error)
+
+	// WarmChunkSize is the maximum number of items written per BatchSet call by Warm (default: 500).
+	// Smaller chunks keep pipelines to remote tiers small.
+	WarmChunkSize int
+
+	// ComputeErrorPolicy controls the values batchComputeFn returns alongside an error:
+	// BatchComputeAllOrNothing (default) discards them, BatchComputePartial caches and returns them.
+	// In both modes the values found in the cache tiers are returned.
+	ComputeErrorPolicy BatchComputeErrorPolicy
+}
+
+// DefaultBatchTieredCacheConfig returns a default configuration
+func DefaultBatchTieredCacheConfig() *BatchTieredCacheConfig {
+	return &BatchTieredCacheConfig{
+		ChunkSize:        0, // chunking disabled
+		Concurrency:      1,
+		BackfillPolicies: nil, // backfill every upper tier
+		OnBackfillError:  nil,
+		WarmChunkSize:    defaultWarmChunkSize,
+
+		ComputeErrorPolicy: BatchComputeAllOrNothing,
+	}
 }
 
 // NewBatchTieredCache creates a new batch tiered cache with dependency injection
 // caches is a slice where caches[0] is L1 (fastest), caches[1] is L2, etc.
 // Empty or nil caches in the slice are skipped
 func NewBatchTieredCache[V any](caches ...BatchCacher[V]) *BatchTieredCache[V] {
+	return NewBatchTieredCacheWithConfig(nil, caches...)
+}
+
+// NewBatchTieredCacheWithConfig creates a new batch tiered cache with the given configuration
+// If config is nil, DefaultBatchTieredCacheConfig is used
+func NewBatchTieredCacheWithConfig[V any](config *BatchTieredCacheConfig, caches ...BatchCacher[V]) *BatchTieredCache[V] {
+	if config == nil {
+		config = DefaultBatchTieredCacheConfig()
+	}
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
 	// Filter out nil caches
 	validCaches := make([]BatchCacher[V], 0, len(caches))
 	for _, cache := range caches {
@@ -28,26 +124,69 @@ func NewBatchTieredCache[V any](caches ...BatchCacher[V]) *BatchTieredCache[V] {
 		}
 	}
 	return &BatchTieredCache[V]{
-		caches: validCaches,
+		caches:        validCaches,
+		chunkSize:     config.ChunkSize,
+		concurrency:   concurrency,
+		backfill:      config.BackfillPolicies,
+		onBackfillErr: config.OnBackfillError,
+		warmChunkSize: config.WarmChunkSize,
+		errorPolicy:   config.ComputeErrorPolicy,
+		inflight:      make(map[string]*batchCall[V]),
 	}
 }
 
 // BatchGet retrieves multiple values using the tiered caching strategy:
 // 1. Check L1, L2, ..., Ln in order using BatchGet
-// 2. For each tier hit, populate upper tiers
-// 3. For all misses, execute batchComputeFn to fetch all at once
+// 2. For each tier hit, populate upper tiers allowed by their BackfillPolicy
+// 3. For all misses not being computed by a concurrent BatchGet, execute batchComputeFn to fetch all at once,
+// and wait for the concurrent BatchGet calls computing the others
 // 4. Populate all tiers with computed values
 // Returns a map of successfully retrieved values (key -> value)
+// Errors from batchComputeFn satisfy errors.Is(err, ErrComputeFailed) and errors from cache tiers
+// satisfy errors.Is(err, ErrCacheBackend); the original error is available via errors.Unwrap
+// When batchComputeFn fails, the returned map holds the values found in the cache tiers and,
+// with BatchComputePartial, the values batchComputeFn returned alongside its error
+// ttl is used both for backfilled and computed values; use BatchGetWithOptions to set them separately
 func (bc *BatchTieredCache[V]) BatchGet(ctx context.Context, keys []string, ttl time.Duration, batchComputeFn BatchComputeFunc[V]) (map[string]V, error) {
+	return bc.BatchGetWithOptions(ctx, keys, BatchGetOptions{ComputeTTL: ttl, BackfillTTL: ttl}, batchComputeFn)
+}
+
+// BatchGetOptions holds per-call options for BatchGetWithOptions
+// A zero TTL falls back to the other one, so setting only ComputeTTL behaves like BatchGet with that TTL.
+// If both are zero (or less), values are written WITHOUT EXPIRY, the same as BatchGet with a zero ttl
+type BatchGetOptions struct {
+	// ComputeTTL is the TTL of values computed by batchComputeFn and written to all tiers
+	// (default: BackfillTTL if zero or negative).
+	ComputeTTL time.Duration
+
+	// BackfillTTL is the TTL of values found in a lower tier and backfilled into the upper tiers
+	// (default: ComputeTTL if zero or negative).
+	BackfillTTL time.Duration
+}
+
+// BatchGetWithOptions retrieves multiple values like BatchGet, with separate TTLs for
+// backfilled and computed values (e.g., a short TTL for L1 backfills of L2 hits)
+// See BatchGetOptions for how zero TTLs are handled
+func (bc *BatchTieredCache[V]) BatchGetWithOptions(ctx context.Context, keys []string, opts BatchGetOptions, batchComputeFn BatchComputeFunc[V]) (map[string]V, error) {
 	if len(keys) == 0 {
 		return make(map[string]V), nil
 	}
+	if opts.ComputeTTL <= 0 {
+		opts.ComputeTTL = opts.BackfillTTL
+	}
+	if opts.BackfillTTL <= 0 {
+		opts.BackfillTTL = opts.ComputeTTL
+	}
+
+	// Deduplicate so tiers and batchComputeFn see each key once
+	// Duplicates share the single entry in the returned map
+	keys = dedupKeys(keys)
 
 	results := make(map[string]V)
 	remainingKeys := keys
 
 	// Try each cache tier in order
-	for _, cache := range bc.caches {
+	for i, cache := range bc.caches {
 		if len(remainingKeys) == 0 {
 			break
 		}
@@ -59,10 +198,10 @@ func (bc *BatchTieredCache[V]) BatchGet(ctx context.Context, keys []string, ttl
 				results[k] = v
 			}
 
-			// TODO: Populate upper tiers if this is L2 or below
+			bc.populateUpperTiers(ctx, tierResults, opts.BackfillTTL, i)
 
 			// Update remaining keys (tier misses)
-			remainingKeys = filterMissingKeys(remainingKeys, tierResults)
+			remainingKeys = FilterMissingKeys(remainingKeys, tierResults)
 		}
 	}
 
@@ -71,58 +210,240 @@ func (bc *BatchTieredCache[V]) BatchGet(ctx context.Context, keys []string, ttl
 		return results, nil
 	}
 
-	// Execute batch compute for remaining keys
-	computedValues, err := batchComputeFn(ctx, remainingKeys)
-	if err != nil {
-		return results, err
+	// Execute batch compute for remaining keys, coalescing with concurrent BatchGet calls
+	computedValues, err := bc.computeCoalesced(ctx, remainingKeys, opts.ComputeTTL, batchComputeFn)
+	for k, v := range computedValues {
+		results[k] = v
 	}
+	return results, err
+}
 
-	if len(computedValues) > 0 {
-		// Add computed values to results
-		for k, v := range computedValues {
+// computeCoalesced computes keys with per-key coalescing across concurrent calls
+// Keys not in flight are claimed and computed together in a single batchCompute call,
+// while keys already in flight are awaited from the call computing them.
+// Returns the values found and the first error from either
+func (bc *BatchTieredCache[V]) computeCoalesced(ctx context.Context, keys []string, ttl time.Duration, batchComputeFn BatchComputeFunc[V]) (map[string]V, error) {
+	owned := make([]string, 0, len(keys))
+	ownedCalls := make(map[string]*batchCall[V], len(keys))
+	waiting := make(map[string]*batchCall[V])
+
+	bc.mu.Lock()
+	for _, key := range keys {
+		if _, ok := ownedCalls[key]; ok {
+			continue
+		}
+		if call, ok := bc.inflight[key]; ok {
+			waiting[key] = call
+			continue
+		}
+		call := &batchCall[V]{done: make(chan struct{})}
+		bc.inflight[key] = call
+		ownedCalls[key] = call
+		owned = append(owned, key)
+	}
+	bc.mu.Unlock()
+
+	results := make(map[string]V, len(keys))
+	var firstErr error
+
+	if len(owned) > 0 {
+		computed, computeErr, setErr := bc.computeOwned(ctx, owned, ttl, batchComputeFn, ownedCalls)
+		for k, v := range computed {
 			results[k] = v
 		}
-		// Populate all caches with computed values
+		firstErr = computeErr
+		if firstErr == nil {
+			firstErr = setErr
+		}
+	}
+
+	for key, call := range waiting {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return results, ctx.Err()
+		}
+		if call.found {
+			results[key] = call.value
+		}
+		if call.err != nil && firstErr == nil {
+			firstErr = call.err
+		}
+	}
+	return results, firstErr
+}
+
+// computeOwned executes batchComputeFn for claimed keys and populates all tiers with the computed values
+// The calls are completed and removed from the in-flight map once the tiers are populated,
+// or if batchComputeFn panics. Waiters receive the compute error but not tier write errors
+// On a compute error, the computed values are discarded or kept according to ComputeErrorPolicy
+func (bc *BatchTieredCache[V]) computeOwned(ctx context.Context, keys []string, ttl time.Duration, batchComputeFn BatchComputeFunc[V], calls map[string]*batchCall[V]) (computed map[string]V, computeErr, setErr error) {
+	defer func() {
+		bc.mu.Lock()
+		defer bc.mu.Unlock()
+		for key, call := range calls {
+			call.value, call.found = computed[key]
+			call.err = computeErr
+			delete(bc.inflight, key)
+			close(call.done)
+		}
+	}()
+
+	computed, err := bc.batchCompute(ctx, keys, batchComputeFn)
+	if err != nil {
+		computeErr = &kindError{kind: ErrComputeFailed, err: err}
+		if bc.errorPolicy != BatchComputePartial {
+			return nil, computeErr, nil
+		}
+	}
+
+	// Populate all caches with computed values
+	if len(computed) > 0 {
 		for _, cache := range bc.caches {
-			if err := cache.BatchSet(ctx, computedValues, ttl); err != nil {
-				return results, err
+			if err := cache.BatchSet(ctx, computed, ttl); err != nil && !errors.Is(err, ErrSetDropped) {
+				setErr = &kindError{kind: ErrCacheBackend, err: err}
+				break
 			}
 		}
 	}
-	return results, nil
+	return computed, computeErr, setErr
+}
+
+// batchCompute executes batchComputeFn for the given keys
+// If chunking is enabled, keys are split into chunks computed in parallel by a bounded worker pool
+// and the results are merged. The first chunk error cancels the remaining chunks and is returned
+// together with the values computed so far
+func (bc *BatchTieredCache[V]) batchCompute(ctx context.Context, keys []string, batchComputeFn BatchComputeFunc[V]) (map[string]V, error) {
+	if bc.chunkSize <= 0 || len(keys) <= bc.chunkSize {
+		return batchComputeFn(ctx, keys)
+	}
+
+	var mu sync.Mutex
+	results := make(map[string]V, len(keys))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(bc.concurrency)
+	for start := 0; start < len(keys); start += bc.chunkSize {
+		chunk := keys[start:min(start+bc.chunkSize, len(keys))]
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			values, err := batchComputeFn(gctx, chunk)
+			mu.Lock()
+			for k, v := range values {
+				results[k] = v
+			}
+			mu.Unlock()
+			return err
+		})
+	}
+	err := g.Wait()
+	return results, err
 }
 
 // BatchSet stores multiple values in all cache tiers
 // All items share the same TTL
+// Writes dropped by a tier (ErrSetDropped) are not treated as failures since caching is best-effort
+// Other errors do not stop the remaining tiers and are returned as a *MultiError naming each failed tier
 func (bc *BatchTieredCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
 	if len(items) == 0 {
 		return nil
 	}
-	for _, cache := range bc.caches {
-		if err := cache.BatchSet(ctx, items, ttl); err != nil {
-			return err
+	var errs []error
+	for i, cache := range bc.caches {
+		if err := cache.BatchSet(ctx, items, ttl); err != nil && !errors.Is(err, ErrSetDropped) {
+			errs = append(errs, tierError(i, err))
 		}
 	}
-	return nil
+	return newMultiError(errs...)
 }
 
-// filterMissingKeys returns keys that are not present in the foundKeys map
-func filterMissingKeys[V any](keys []string, foundKeys map[string]V) []string {
-	missing := make([]string, 0, len(keys))
-	for _, key := range keys {
-		if _, found := foundKeys[key]; !found {
-			missing = append(missing, key)
+// Warm preloads items into all cache tiers, e.g., from a known dataset on startup
+// Items are written in chunks of WarmChunkSize with BatchSet.
+// Writes dropped by a tier (ErrSetDropped) are not treated as failures; other errors do not stop
+// warming the remaining tiers and are returned as a *MultiError naming each failed tier
+func (bc *BatchTieredCache[V]) Warm(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	var errs []error
+	for i, cache := range bc.caches {
+		if err := warmCache[V](ctx, cache, items, ttl, bc.warmChunkSize); err != nil {
+			errs = append(errs, tierError(i, err))
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// BatchDelete removes multiple keys from all cache tiers
+// Missing keys are ignored; other errors do not stop the remaining deletes and are returned
+// as a *MultiError naming each failed tier (e.g., "L2: ...")
+func (bc *BatchTieredCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	var errs []error
+	for i, cache := range bc.caches {
+		if err := cache.BatchDelete(ctx, keys); err != nil {
+			errs = append(errs, tierError(i, err))
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// Clear removes all items from every cache tier that supports it
+// All tiers are cleared even if some fail, and the errors are returned as a *MultiError naming each failed tier
+func (bc *BatchTieredCache[V]) Clear(ctx context.Context) error {
+	var errs []error
+	for i, cache := range bc.caches {
+		if err := clearCache(ctx, cache); err != nil {
+			errs = append(errs, tierError(i, err))
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// Close closes every cache tier implementing io.Closer
+// All tiers are closed even if some fail, and the errors are returned as a *MultiError naming each failed tier
+func (bc *BatchTieredCache[V]) Close() error {
+	var errs []error
+	for i, cache := range bc.caches {
+		if err := closeCache(cache); err != nil {
+			errs = append(errs, tierError(i, err))
 		}
 	}
-	return missing
+	return newMultiError(errs...)
 }
 
-// populateUpperTiers writes values to all cache tiers above the specified tier
-// func (bc *BatchTieredCache[V]) populateUpperTiers(ctx context.Context, items map[string]V, ttl time.Duration, foundTierIndex int) error {
-// 	for i := 0; i < foundTierIndex && i < len(bc.caches); i++ {
-// 		if err := bc.caches[i].BatchSet(ctx, items, ttl); err != nil {
-// 			return err
-// 		}
-// 	}
-// 	return nil
-// }
+// HealthCheck pings every cache tier implementing Pinger
+// Returns nil if all tiers are healthy, otherwise a *MultiError naming each unhealthy tier (e.g., "L2: ...")
+// Each error matches ErrCacheUnavailable, or ctx.Err() if ctx ended before the tier answered
+func (bc *BatchTieredCache[V]) HealthCheck(ctx context.Context) error {
+	var errs []error
+	for i, cache := range bc.caches {
+		if err := pingCache(ctx, i, cache); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// populateUpperTiers writes values to the cache tiers above the specified tier allowed by their BackfillPolicy
+// Backfill is best-effort, so write errors do not fail the read that found the values
+// and are only reported to OnBackfillError
+func (bc *BatchTieredCache[V]) populateUpperTiers(ctx context.Context, items map[string]V, ttl time.Duration, foundTierIndex int) {
+	for i := 0; i < foundTierIndex && i < len(bc.caches); i++ {
+		backfillItems := make(map[string]V, len(items))
+		for key, value := range items {
+			if shouldBackfill(bc.backfill, key, foundTierIndex, i) {
+				backfillItems[key] = value
+			}
+		}
+		if len(backfillItems) == 0 {
+			continue
+		}
+		if err := bc.caches[i].BatchSet(ctx, backfillItems, ttl); err != nil && bc.onBackfillErr != nil {
+			for key := range backfillItems {
+				bc.onBackfillErr(key, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// computeCounter is a BatchComputeFunc that returns "v-<key>" for every key and counts how often each key is computed
+type computeCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newComputeCounter() *computeCounter {
+	return &computeCounter{calls: make(map[string]int)}
+}
+
+func (c *computeCounter) compute(ctx context.Context, keys []string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		c.calls[key]++
+		values[key] = "v-" + key
+	}
+	return values, nil
+}
+
+func (c *computeCounter) counts() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.calls)
+}
+
+func TestBatchTieredCacheCoalescesOverlappingBatches(t *testing.T) {
+	ctx := context.Background()
+	bc := NewBatchTieredCache[string](NewLRUCache[string](nil))
+	counter := newComputeCounter()
+
+	// The first batch claims "a" and "b" and blocks in batchComputeFn until released
+	started := make(chan struct{})
+	release := make(chan struct{})
+	first := make(chan map[string]string, 1)
+	go func() {
+		values, err := bc.BatchGet(ctx, []string{"a", "b"}, time.Hour, func(ctx context.Context, keys []string) (map[string]string, error) {
+			close(started)
+			<-release
+			return counter.compute(ctx, keys)
+		})
+		if err != nil {
+			t.Errorf("first BatchGet: %v", err)
+		}
+		first <- values
+	}()
+	<-started
+
+	// The second batch overlaps on "b": it computes only "c", then waits for the first batch to deliver "b".
+	// Its batchComputeFn runs after every key is claimed or awaited, so the first batch can be released from it
+	second, err := bc.BatchGet(ctx, []string{"b", "c"}, time.Hour, func(ctx context.Context, keys []string) (map[string]string, error) {
+		close(release)
+		return counter.compute(ctx, keys)
+	})
+	if err != nil {
+		t.Fatalf("second BatchGet: %v", err)
+	}
+
+	if want := map[string]string{"b": "v-b", "c": "v-c"}; !maps.Equal(second, want) {
+		t.Errorf("second BatchGet = %v, want %v", second, want)
+	}
+	if got, want := <-first, map[string]string{"a": "v-a", "b": "v-b"}; !maps.Equal(got, want) {
+		t.Errorf("first BatchGet = %v, want %v", got, want)
+	}
+	if got, want := counter.counts(), map[string]int{"a": 1, "b": 1, "c": 1}; !maps.Equal(got, want) {
+		t.Errorf("compute calls per key = %v, want %v", got, want)
+	}
+}
+
+func TestBatchTieredCacheDeduplicatesKeys(t *testing.T) {
+	ctx := context.Background()
+	bc := NewBatchTieredCache[string](NewLRUCache[string](nil))
+	counter := newComputeCounter()
+
+	var computedKeys [][]string
+	values, err := bc.BatchGet(ctx, []string{"a", "b", "a", "c", "b"}, time.Hour, func(ctx context.Context, keys []string) (map[string]string, error) {
+		computedKeys = append(computedKeys, keys)
+		return counter.compute(ctx, keys)
+	})
+	if err != nil {
+		t.Fatalf("BatchGet: %v", err)
+	}
+
+	if len(computedKeys) != 1 || !slices.Equal(computedKeys[0], []string{"a", "b", "c"}) {
+		t.Errorf("batchComputeFn keys = %v, want [[a b c]]", computedKeys)
+	}
+	if got, want := counter.counts(), map[string]int{"a": 1, "b": 1, "c": 1}; !maps.Equal(got, want) {
+		t.Errorf("compute calls per key = %v, want %v", got, want)
+	}
+	if want := map[string]string{"a": "v-a", "b": "v-b", "c": "v-c"}; !maps.Equal(values, want) {
+		t.Errorf("BatchGet = %v, want %v", values, want)
+	}
+}
+
+func TestBatchTieredCacheWarmContinuesAfterTierError(t *testing.T) {
+	ctx := context.Background()
+	l2 := NewLRUCache[string](nil)
+	bc := NewBatchTieredCache[string](&failingBatchCache{NewMapCache[string]()}, l2)
+
+	err := bc.Warm(ctx, map[string]string{"a": "1"}, time.Hour)
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 1 || !strings.HasPrefix(multi.Errors[0].Error(), "L1: ") {
+		t.Fatalf("Warm = %v, want a *MultiError naming L1", err)
+	}
+	if got, err := l2.Get(ctx, "a"); err != nil || got != "1" {
+		t.Errorf("L2 Get = %q, %v; want %q, nil", got, err, "1")
+	}
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// FixedNumber is the set of numeric types with a fixed binary size
+// int and uint are excluded since their size depends on the platform
+type FixedNumber interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// BinaryCoder implements Coder for numeric values using fixed-width big-endian encoding
+// A value takes exactly its size in bytes (e.g., 8 for int64), which is smaller and faster than JSON
+// or MessagePack for scalar values such as counters cached by the million
+type BinaryCoder[V FixedNumber] struct{}
+
+// NewBinaryCoder creates a new BinaryCoder instance
+func NewBinaryCoder[V FixedNumber]() *BinaryCoder[V] {
+	return &BinaryCoder[V]{}
+}
+
+// NewInt64Coder creates a new BinaryCoder for int64 values
+func NewInt64Coder() *BinaryCoder[int64] {
+	return NewBinaryCoder[int64]()
+}
+
+// NewUint64Coder creates a new BinaryCoder for uint64 values
+func NewUint64Coder() *BinaryCoder[uint64] {
+	return NewBinaryCoder[uint64]()
+}
+
+// NewFloat64Coder creates a new BinaryCoder for float64 values
+func NewFloat64Coder() *BinaryCoder[float64] {
+	return NewBinaryCoder[float64]()
+}
+
+// Encode serializes a value to its fixed-width big-endian bytes
+func (c *BinaryCoder[V]) Encode(value V) ([]byte, error) {
+	return binary.Append(nil, binary.BigEndian, value)
+}
+
+// Decode deserializes fixed-width big-endian bytes to a value
+// Returns an error if data is not exactly the size of V
+func (c *BinaryCoder[V]) Decode(data []byte) (V, error) {
+	var value V
+	if size := binary.Size(value); len(data) != size {
+		return value, fmt.Errorf("cache: binary decode: got %d bytes, want %d", len(data), size)
+	}
+	if _, err := binary.Decode(data, binary.BigEndian, &value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinaryCoderRoundTrip(t *testing.T) {
+	t.Run("int64", func(t *testing.T) {
+		testBinaryCoderRoundTrip(t, NewInt64Coder(), 8, 0, 1, -1, math.MinInt64, math.MaxInt64)
+	})
+	t.Run("uint64", func(t *testing.T) {
+		testBinaryCoderRoundTrip(t, NewUint64Coder(), 8, 0, 1, math.MaxUint64)
+	})
+	t.Run("float64", func(t *testing.T) {
+		testBinaryCoderRoundTrip(t, NewFloat64Coder(), 8, 0, -1.5, math.Pi, math.MaxFloat64, math.Inf(-1))
+	})
+	t.Run("int8", func(t *testing.T) {
+		testBinaryCoderRoundTrip(t, NewBinaryCoder[int8](), 1, 0, -1, math.MinInt8, math.MaxInt8)
+	})
+}
+
+func testBinaryCoderRoundTrip[V FixedNumber](t *testing.T, coder *BinaryCoder[V], size int, values ...V) {
+	t.Helper()
+	for _, want := range values {
+		data, err := coder.Encode(want)
+		if err != nil {
+			t.Fatalf("Encode(%v): %v", want, err)
+		}
+		if len(data) != size {
+			t.Errorf("Encode(%v) = %d bytes, want %d", want, len(data), size)
+		}
+		got, err := coder.Decode(data)
+		if err != nil || got != want {
+			t.Errorf("Decode(Encode(%v)) = %v, %v; want %v, nil", want, got, err, want)
+		}
+	}
+}
+
+func TestBinaryCoderRejectsWrongSize(t *testing.T) {
+	coder := NewInt64Coder()
+	for _, data := range [][]byte{nil, make([]byte, 7), make([]byte, 9)} {
+		if _, err := coder.Decode(data); err == nil {
+			t.Errorf("Decode(%d bytes) succeeded, want an error", len(data))
+		}
+	}
+}
+
+// BenchmarkBinaryCoderInt64 compares BinaryCoder with JSON and MessagePack for int64 values
+func BenchmarkBinaryCoderInt64(b *testing.B) {
+	benchmarkCoders(b, int64(1234567890123), []namedCoder[int64]{
+		{"Binary", NewInt64Coder()},
+		{"JSON", NewJSONCoder[int64]()},
+		{"MessagePack", NewMessagePackCoder[int64]()},
+	})
+}
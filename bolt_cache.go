@@ -0,0 +1,381 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// boltHeaderSize is the size of the expiry header prefixed to each stored value
+const boltHeaderSize = 8
+
+// BoltCache implements the BatchCacher interface with a persistent local cache backed by bbolt
+// Values are encoded with a Coder and stored in a single bucket, prefixed with their expiry time,
+// so the cache survives process restarts. Expired entries are removed lazily when they are read
+// and periodically by a background compaction goroutine
+type BoltCache[V any] struct {
+	db     *bbolt.DB
+	bucket []byte
+	coder  Coder[V]
+	clock  Clock
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// BoltCacheConfig holds configuration for BoltCache
+type BoltCacheConfig struct {
+	// Path is the path of the database file, created if it does not exist
+	Path string
+
+	// Bucket is the name of the bucket holding the cache entries
+	Bucket string
+
+	// OpenTimeout is how long to wait for the file lock when opening the database.
+	// bbolt allows a single process to open a file; 0 waits indefinitely.
+	OpenTimeout time.Duration
+
+	// CompactionInterval is how often expired entries are removed in the background.
+	// If zero or negative, expired entries are only removed when they are read.
+	CompactionInterval time.Duration
+
+	// Clock is the time source for TTLs and the compaction interval (default: the system clock).
+	Clock Clock
+}
+
+// DefaultBoltCacheConfig returns a default configuration
+func DefaultBoltCacheConfig() *BoltCacheConfig {
+	return &BoltCacheConfig{
+		Path:               "cache.db",
+		Bucket:             "cache",
+		OpenTimeout:        time.Second,
+		CompactionInterval: 10 * time.Minute,
+		Clock:              RealClock(),
+	}
+}
+
+// NewBoltCache opens (or creates) the database file, creates the bucket if needed,
+// and starts the compaction goroutine if CompactionInterval is positive
+// If coder is nil, JSONCoder is used
+func NewBoltCache[V any](config *BoltCacheConfig, coder Coder[V]) (*BoltCache[V], error) {
+	if config == nil {
+		config = DefaultBoltCacheConfig()
+	}
+	if coder == nil {
+		coder = NewJSONCoder[V]()
+	}
+
+	db, err := bbolt.Open(config.Path, 0o600, &bbolt.Options{Timeout: config.OpenTimeout})
+	if err != nil {
+		return nil, err
+	}
+	bucket := []byte(config.Bucket)
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	b := &BoltCache[V]{
+		db:     db,
+		bucket: bucket,
+		coder:  coder,
+		clock:  clockOrReal(config.Clock),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if config.CompactionInterval > 0 {
+		go b.compactLoop(config.CompactionInterval)
+	} else {
+		close(b.done)
+	}
+	return b, nil
+}
+
+// Get retrieves a value from the cache
+// An expired entry is deleted and reported as ErrCacheMiss
+func (b *BoltCache[V]) Get(ctx context.Context, key string) (V, error) {
+	var zero V
+	var data []byte
+	expired := false
+	now := b.clock.Now()
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(b.bucket).Get([]byte(key))
+		if raw == nil {
+			return ErrCacheMiss
+		}
+		payload, ok := boltPayload(raw, now)
+		if !ok {
+			expired = true
+			return ErrCacheMiss
+		}
+		// raw is only valid during the transaction
+		data = append([]byte(nil), payload...)
+		return nil
+	})
+	if err != nil {
+		if expired {
+			b.deleteExpired([][]byte{[]byte(key)})
+		}
+		return zero, err
+	}
+	return b.coder.Decode(data)
+}
+
+// Set stores a value in the cache with a TTL
+func (b *BoltCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	data, err := b.encode(value, ttl, b.clock.Now())
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(b.bucket).Put([]byte(key), data)
+	})
+}
+
+// GetTTL returns the remaining time-to-live of a key
+// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+func (b *BoltCache[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	var ttl time.Duration
+	now := b.clock.Now()
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(b.bucket).Get([]byte(key))
+		if raw == nil {
+			return ErrCacheMiss
+		}
+		if _, ok := boltPayload(raw, now); !ok {
+			return ErrCacheMiss
+		}
+		expiresAt := int64(binary.BigEndian.Uint64(raw[:boltHeaderSize]))
+		if expiresAt == 0 {
+			return ErrNoExpiry
+		}
+		ttl = time.Unix(0, expiresAt).Sub(now)
+		return nil
+	})
+	return ttl, err
+}
+
+// Delete removes a value from the cache
+// Returns ErrCacheMiss if the key is not found or has expired
+func (b *BoltCache[V]) Delete(ctx context.Context, key string) error {
+	now := b.clock.Now()
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		raw := bucket.Get([]byte(key))
+		if raw == nil {
+			return ErrCacheMiss
+		}
+		_, live := boltPayload(raw, now)
+		if err := bucket.Delete([]byte(key)); err != nil {
+			return err
+		}
+		if !live {
+			return ErrCacheMiss
+		}
+		return nil
+	})
+}
+
+// BatchGet retrieves multiple values from the cache in a single read transaction
+// Returns a map of key-value pairs for found keys
+// Missing, expired and undecodable keys are not included in the returned map
+func (b *BoltCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	found := make(map[string][]byte, len(keys))
+	var expired [][]byte
+	now := b.clock.Now()
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		for _, key := range keys {
+			raw := bucket.Get([]byte(key))
+			if raw == nil {
+				continue
+			}
+			payload, ok := boltPayload(raw, now)
+			if !ok {
+				expired = append(expired, []byte(key))
+				continue
+			}
+			found[key] = append([]byte(nil), payload...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(expired) > 0 {
+		b.deleteExpired(expired)
+	}
+
+	results := make(map[string]V, len(found))
+	for key, data := range found {
+		value, err := b.coder.Decode(data)
+		if err != nil {
+			continue
+		}
+		results[key] = value
+	}
+	return results, nil
+}
+
+// BatchSet stores multiple values in the cache with a TTL in a single write transaction
+// All items share the same TTL
+func (b *BoltCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+	now := b.clock.Now()
+	encoded := make(map[string][]byte, len(items))
+	for key, value := range items {
+		data, err := b.encode(value, ttl, now)
+		if err != nil {
+			return err
+		}
+		encoded[key] = data
+	}
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		for key, data := range encoded {
+			if err := bucket.Put([]byte(key), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// BatchDelete removes multiple values from the cache in a single write transaction
+// Missing keys are ignored
+func (b *BoltCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		for _, key := range keys {
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Clear removes all items from the cache by recreating the bucket
+func (b *BoltCache[V]) Clear(ctx context.Context) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket(b.bucket); err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+			return err
+		}
+		_, err := tx.CreateBucket(b.bucket)
+		return err
+	})
+}
+
+// Compact removes all expired entries
+func (b *BoltCache[V]) Compact(ctx context.Context) error {
+	now := b.clock.Now()
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		// Collect keys first since deleting while iterating makes the cursor skip entries
+		var expired [][]byte
+		if err := bucket.ForEach(func(k, v []byte) error {
+			if _, ok := boltPayload(v, now); !ok {
+				expired = append(expired, append([]byte(nil), k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close stops the compaction goroutine, syncs the database to disk and closes it
+func (b *BoltCache[V]) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.stop)
+		<-b.done
+		b.closeErr = errors.Join(b.db.Sync(), b.db.Close())
+	})
+	return b.closeErr
+}
+
+// compactLoop removes expired entries periodically until Close is called
+func (b *BoltCache[V]) compactLoop(interval time.Duration) {
+	defer close(b.done)
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-b.clock.After(interval):
+			// Failed compactions are retried on the next tick
+			_ = b.Compact(context.Background())
+		}
+	}
+}
+
+// deleteExpired removes keys found expired by a read, unless they were rewritten in the meantime
+// Failures are ignored since the entries are removed again on the next read or compaction
+func (b *BoltCache[V]) deleteExpired(keys [][]byte) {
+	now := b.clock.Now()
+	_ = b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		for _, key := range keys {
+			raw := bucket.Get(key)
+			if raw == nil {
+				continue
+			}
+			if _, ok := boltPayload(raw, now); ok {
+				continue
+			}
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// encode serializes a value with the configured coder and prefixes it with its expiry time
+// The expiry is stored as big-endian Unix nanoseconds, where 0 means no expiry
+func (b *BoltCache[V]) encode(value V, ttl time.Duration, now time.Time) ([]byte, error) {
+	data, err := b.coder.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = now.Add(ttl).UnixNano()
+	}
+	out := make([]byte, boltHeaderSize, boltHeaderSize+len(data))
+	binary.BigEndian.PutUint64(out, uint64(expiresAt))
+	return append(out, data...), nil
+}
+
+// boltPayload returns the encoded value of a stored entry and whether the entry is live at now
+// Malformed entries are treated as expired
+func boltPayload(raw []byte, now time.Time) ([]byte, bool) {
+	if len(raw) < boltHeaderSize {
+		return nil, false
+	}
+	expiresAt := int64(binary.BigEndian.Uint64(raw[:boltHeaderSize]))
+	if expiresAt != 0 && now.UnixNano() >= expiresAt {
+		return nil, false
+	}
+	return raw[boltHeaderSize:], true
+}
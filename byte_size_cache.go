@@ -0,0 +1,285 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SizeFunc returns the size in bytes that an item accounts for in a ByteSizeCache
+type SizeFunc[V any] func(key string, value V) int
+
+// ByteSizeCache implements the BatchCacher interface with an in-memory least-recently-used cache
+// bounded by the total size of its items in bytes rather than their number
+// Each item's size is measured once when it is written, as the length of its key plus the length of
+// the value encoded with a Coder (or as returned by a SizeFunc), and least recently used items are evicted
+// until the total is within MaxBytes. Unlike Ristretto's cost accounting, writes are synchronous and
+// the budget is enforced exactly, which keeps memory predictable for values of very different sizes.
+// Values are stored as-is; the encoding is only used to measure them. Expired items are removed lazily when they are read
+type ByteSizeCache[V any] struct {
+	mu        sync.Mutex
+	maxBytes  int64
+	sizeFunc  func(key string, value V) (int, error)
+	lru       lruList[V]
+	evictions uint64
+	clock     Clock
+}
+
+// ByteSizeCacheConfig holds configuration for ByteSizeCache
+type ByteSizeCacheConfig[V any] struct {
+	// MaxBytes is the maximum total size of the items in bytes.
+	// When a write exceeds it, least recently used items are evicted until the total fits.
+	MaxBytes int64
+
+	// Coder measures the size of values by encoding them (default: JSONCoder).
+	// Only used when SizeFunc is nil.
+	Coder Coder[V]
+
+	// SizeFunc returns the size of an item (optional).
+	// Use it when the size can be computed cheaply without encoding, e.g., the length of a string.
+	SizeFunc SizeFunc[V]
+
+	// Clock is the time source for TTLs (default: the system clock).
+	Clock Clock
+}
+
+// DefaultByteSizeCacheConfig returns a default configuration
+func DefaultByteSizeCacheConfig[V any]() *ByteSizeCacheConfig[V] {
+	return &ByteSizeCacheConfig[V]{
+		MaxBytes: 64 << 20, // 64 MiB
+		Coder:    NewJSONCoder[V](),
+		SizeFunc: nil,
+		Clock:    RealClock(),
+	}
+}
+
+// ByteSizeStats holds statistics of a ByteSizeCache
+type ByteSizeStats struct {
+	// Size is the number of items in the cache, including expired items not yet removed
+	Size int
+
+	// SizeBytes is the total size of the items in bytes
+	SizeBytes int64
+
+	// Evictions is the number of items evicted to stay within MaxBytes
+	Evictions uint64
+}
+
+// NewByteSizeCache creates a new ByteSizeCache instance
+func NewByteSizeCache[V any](config *ByteSizeCacheConfig[V]) *ByteSizeCache[V] {
+	if config == nil {
+		config = DefaultByteSizeCacheConfig[V]()
+	}
+	var sizeFunc func(key string, value V) (int, error)
+	if config.SizeFunc != nil {
+		sizeFunc = func(key string, value V) (int, error) {
+			return config.SizeFunc(key, value), nil
+		}
+	} else {
+		coder := config.Coder
+		if coder == nil {
+			coder = NewJSONCoder[V]()
+		}
+		sizeFunc = encodedSize(coder)
+	}
+	return &ByteSizeCache[V]{
+		maxBytes: config.MaxBytes,
+		sizeFunc: sizeFunc,
+		lru:      newLRUList[V](),
+		clock:    clockOrReal(config.Clock),
+	}
+}
+
+// encodedSize returns a size function measuring the key plus the value encoded with coder
+// The error of a value that fails to encode is returned as-is
+func encodedSize[V any](coder Coder[V]) func(key string, value V) (int, error) {
+	return func(key string, value V) (int, error) {
+		data, err := coder.Encode(value)
+		if err != nil {
+			return 0, err
+		}
+		return len(key) + len(data), nil
+	}
+}
+
+// Get retrieves a value from the cache
+func (c *ByteSizeCache[V]) Get(ctx context.Context, key string) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	entry, ok := c.lru.get(key, c.clock.Now())
+	if !ok {
+		return zero, ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+// Set stores a value in the cache with a TTL, evicting least recently used items to stay within MaxBytes
+// A TTL of zero or less means the item does not expire
+// Returns ErrValueTooLarge if the item alone exceeds MaxBytes; a previous value of the key is removed then,
+// so the cache never serves a value older than the rejected one
+func (c *ByteSizeCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	// Measure outside the lock since encoding can be expensive
+	size, err := c.measure(key, value)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		c.lru.remove(key)
+		return err
+	}
+	c.set(key, value, size, ttl, c.clock.Now())
+	return nil
+}
+
+// GetTTL returns the remaining time-to-live of a key
+// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+func (c *ByteSizeCache[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	entry, ok := c.lru.get(key, now)
+	if !ok {
+		return 0, ErrCacheMiss
+	}
+	if entry.expiresAt.IsZero() {
+		return 0, ErrNoExpiry
+	}
+	return entry.expiresAt.Sub(now), nil
+}
+
+// Delete removes a value from the cache
+func (c *ByteSizeCache[V]) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.lru.get(key, c.clock.Now()); !ok {
+		return ErrCacheMiss
+	}
+	c.lru.remove(key)
+	return nil
+}
+
+// BatchGet retrieves multiple values from the cache
+// Returns a map of key-value pairs for found keys
+// Missing keys are simply not included in the returned map
+func (c *ByteSizeCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	results := make(map[string]V, len(keys))
+	for _, key := range keys {
+		if entry, ok := c.lru.get(key, now); ok {
+			results[key] = entry.value
+		}
+	}
+	return results, nil
+}
+
+// BatchSet stores multiple values in the cache with a TTL
+// All items share the same TTL. Every item is measured first; if one fails (e.g., exceeds MaxBytes),
+// the error is returned and nothing is written
+func (c *ByteSizeCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	sizes := make(map[string]int64, len(items))
+	for key, value := range items {
+		size, err := c.measure(key, value)
+		if err != nil {
+			return err
+		}
+		sizes[key] = size
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for key, value := range items {
+		c.set(key, value, sizes[key], ttl, now)
+	}
+	return nil
+}
+
+// BatchDelete removes multiple values from the cache
+// Missing keys are ignored
+func (c *ByteSizeCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		c.lru.remove(key)
+	}
+	return nil
+}
+
+// Len returns the number of items in the cache, including expired items not yet removed
+func (c *ByteSizeCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.len()
+}
+
+// SizeBytes returns the total size of the items in bytes, including expired items not yet removed
+func (c *ByteSizeCache[V]) SizeBytes() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.size
+}
+
+// Stats returns the current size and the number of evictions since the cache was created
+func (c *ByteSizeCache[V]) Stats() ByteSizeStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return ByteSizeStats{
+		Size:      c.lru.len(),
+		SizeBytes: c.lru.size,
+		Evictions: c.evictions,
+	}
+}
+
+// Clear removes all items from the cache
+// The eviction count is kept
+func (c *ByteSizeCache[V]) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.clear()
+	return nil
+}
+
+// measure returns the size of an item
+// Returns ErrValueTooLarge if the item alone exceeds MaxBytes, or an error wrapping the encoding error
+// if the value cannot be measured
+func (c *ByteSizeCache[V]) measure(key string, value V) (int64, error) {
+	n, err := c.sizeFunc(key, value)
+	if err != nil {
+		return 0, fmt.Errorf("cache: cannot measure the size of key %q: %w", key, err)
+	}
+	size := int64(n)
+	if size < 0 {
+		return 0, fmt.Errorf("cache: cannot measure the size of key %q", key)
+	}
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return 0, fmt.Errorf("%w: key %q is %d bytes (max %d)", ErrValueTooLarge, key, size, c.maxBytes)
+	}
+	return size, nil
+}
+
+// set stores a value of the given size and evicts least recently used items until the total fits MaxBytes
+// Callers must hold c.mu
+func (c *ByteSizeCache[V]) set(key string, value V, size int64, ttl time.Duration, now time.Time) {
+	c.lru.set(key, value, size, ttl, now)
+
+	// The item just written is at the front and fits on its own, so eviction stops before reaching it
+	for c.maxBytes > 0 && c.lru.size > c.maxBytes {
+		c.lru.removeOldest()
+		c.evictions++
+	}
+}
@@ -0,0 +1,19 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestByteSizeCacheSetWrapsEncodeError(t *testing.T) {
+	c := NewByteSizeCache[any](nil)
+
+	err := c.Set(context.Background(), "a", make(chan int), time.Hour)
+	var unsupported *json.UnsupportedTypeError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("Set = %v, want an error wrapping *json.UnsupportedTypeError", err)
+	}
+}
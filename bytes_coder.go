@@ -0,0 +1,26 @@
+package cache
+
+// BytesCoder implements Coder for values that are already serialized
+// Encode and Decode pass the bytes through unchanged, avoiding the overhead of JSON
+// (which would base64-encode a []byte) for precomputed blobs
+type BytesCoder struct{}
+
+// NewBytesCoder creates a new BytesCoder instance
+func NewBytesCoder() *BytesCoder {
+	return &BytesCoder{}
+}
+
+// Encode returns the value unchanged
+func (c *BytesCoder) Encode(value []byte) ([]byte, error) {
+	return value, nil
+}
+
+// Decode returns a copy of the data so the caller owns the returned slice
+func (c *BytesCoder) Decode(data []byte) ([]byte, error) {
+	if data == nil {
+		return nil, nil
+	}
+	value := make([]byte, len(data))
+	copy(value, data)
+	return value, nil
+}
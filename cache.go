@@ -3,14 +3,168 @@ package cache
 import (
 	"context"
 	"errors"
+	"fmt"
+	"iter"
+	"strings"
 	"time"
 )
 
 var (
 	// ErrCacheMiss indicates the key was not found in cache
 	ErrCacheMiss = errors.New("cache miss")
+
+	// ErrNotFound indicates the value does not exist in the underlying data source
+	// Compute functions return it (optionally wrapped) to signal a negative result that can be cached,
+	// and caches return it for keys holding a negative cache entry
+	ErrNotFound = errors.New("not found")
+
+	// ErrNoExpiry indicates the key exists but has no expiry
+	ErrNoExpiry = errors.New("key has no expiry")
+
+	// ErrFlushNotAllowed indicates Clear was called on a cache that has not opted in to flushing
+	ErrFlushNotAllowed = errors.New("flush not allowed")
+
+	// ErrReadOnly indicates a write was rejected because the cache is read-only (see ReadOnlyCacher)
+	ErrReadOnly = errors.New("cache is read-only")
+
+	// ErrUnsupported indicates the cache implementation cannot support the operation
+	ErrUnsupported = errors.New("operation not supported")
+
+	// ErrSetDropped indicates the cache accepted the call but dropped the write
+	// (e.g., ristretto's admission policy or buffer contention), so the value is not stored
+	// Callers can decide whether to retry; tiered caches treat it as a best-effort miss
+	ErrSetDropped = errors.New("set dropped")
+
+	// ErrValueTooLarge indicates an encoded value exceeds the configured size limit and was not written
+	ErrValueTooLarge = errors.New("value too large")
+
+	// ErrInvalidValue indicates a value failed validation, e.g., a decoded value rejected by ValidatingCoder
+	// The validation error is available via errors.Unwrap
+	ErrInvalidValue = errors.New("invalid value")
+
+	// ErrKeyTooLong indicates a key exceeds the configured length limit and no command was sent for it
+	ErrKeyTooLong = errors.New("key too long")
+
+	// ErrBatchTooLarge indicates a batch operation has more keys than the configured limit and was not executed
+	ErrBatchTooLarge = errors.New("batch too large")
+
+	// ErrComputeFailed indicates the compute function of a tiered cache failed
+	// The compute function's error is available via errors.Unwrap
+	ErrComputeFailed = errors.New("compute failed")
+
+	// ErrCacheBackend indicates a cache tier failed while reading or writing a value
+	// The tier's error is available via errors.Unwrap
+	ErrCacheBackend = errors.New("cache backend error")
+
+	// ErrCacheUnavailable indicates a health check found the cache backend unreachable or unresponsive,
+	// as opposed to the caller's context expiring first
+	// The backend's error is available via errors.Unwrap
+	ErrCacheUnavailable = errors.New("cache unavailable")
+
+	// ErrCircuitOpen indicates an operation was not attempted because a circuit breaker is open
+	// (see CircuitBreakerCacher). Skipped writes also match ErrSetDropped
+	ErrCircuitOpen = errors.New("circuit open")
+
+	// ErrLockNotHeld indicates a distributed lock could not be released because it is no longer held,
+	// i.e., it expired and may have been acquired by another owner
+	ErrLockNotHeld = errors.New("lock not held")
 )
 
+// isRejection reports whether err is a deterministic rejection of the call itself (e.g., an oversized value
+// or a read-only cache) rather than a failure of the backend; repeating the call cannot change the outcome
+func isRejection(err error) bool {
+	for _, target := range []error{
+		ErrSetDropped, ErrValueTooLarge, ErrKeyTooLong, ErrBatchTooLarge, ErrInvalidValue,
+		ErrReadOnly, ErrUnsupported, ErrFlushNotAllowed, ErrCircuitOpen,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// kindError classifies an error with a sentinel while keeping the original error as its cause
+// errors.Is matches both the sentinel and the cause, and errors.Unwrap returns the cause
+type kindError struct {
+	kind error
+	err  error
+}
+
+// Error returns the sentinel message followed by the cause
+func (e *kindError) Error() string {
+	return e.kind.Error() + ": " + e.err.Error()
+}
+
+// Unwrap returns the cause
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is the sentinel
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+// MultiError holds the errors of an operation fanned out to several cache tiers or keys
+// errors.Is and errors.As match any of the errors, and errors.As(err, &multiErr) with a *MultiError
+// gives access to each of them
+type MultiError struct {
+	Errors []error
+}
+
+// Error returns the messages of all errors separated by newlines
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// newMultiError returns a *MultiError holding the non-nil errors, or nil if there are none
+func newMultiError(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: nonNil}
+}
+
+// tierError annotates an error with the tier name derived from tierIndex (0 = L1)
+func tierError(tierIndex int, err error) error {
+	return fmt.Errorf("L%d: %w", tierIndex+1, err)
+}
+
+// classifyPingError classifies an error returned by a health check
+// If ctx is done, the error is attributed to the caller and matches ctx.Err() (context.DeadlineExceeded
+// or context.Canceled); otherwise it matches ErrCacheUnavailable. The original error stays unwrappable
+func classifyPingError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if errors.Is(err, ctxErr) {
+			return err
+		}
+		return &kindError{kind: ctxErr, err: err}
+	}
+	if errors.Is(err, ErrCacheUnavailable) {
+		return err
+	}
+	return &kindError{kind: ErrCacheUnavailable, err: err}
+}
+
 // Cacher defines the unified interface for cache implementations (local or remote)
 // This interface can be used for multi-tier caching where caches[0] is L1, caches[1] is L2, etc.
 type Cacher[V any] interface {
@@ -19,6 +173,7 @@ type Cacher[V any] interface {
 	Get(ctx context.Context, key string) (V, error)
 
 	// Set stores a value in cache with a TTL
+	// A TTL of zero or less means no expiry
 	Set(ctx context.Context, key string, value V, ttl time.Duration) error
 
 	// Delete removes a value from cache
@@ -36,30 +191,153 @@ type BatchCacher[V any] interface {
 	BatchGet(ctx context.Context, keys []string) (map[string]V, error)
 
 	// BatchSet stores multiple values in cache with a TTL
-	// All items share the same TTL
+	// All items share the same TTL, and a TTL of zero or less means no expiry
 	BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error
+
+	// BatchDelete removes multiple values from cache
+	// Missing keys are ignored and do not cause an error
+	BatchDelete(ctx context.Context, keys []string) error
+}
+
+// NegativeCacher defines the interface for cache implementations that can store negative results
+type NegativeCacher interface {
+	// SetNotFound stores a negative cache entry (tombstone) with a TTL
+	// Subsequent Get calls for the key return ErrNotFound until the entry expires or is overwritten
+	SetNotFound(ctx context.Context, key string, ttl time.Duration) error
+}
+
+// TTLer defines the interface for cache implementations that can report the remaining TTL of a key
+type TTLer interface {
+	// GetTTL returns the remaining time-to-live of a key
+	// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+	GetTTL(ctx context.Context, key string) (time.Duration, error)
+}
+
+// Scanner defines the interface for cache implementations that can enumerate keys
+type Scanner interface {
+	// Scan iterates over the keys matching a glob-style pattern (e.g., "user:*")
+	// Keys are fetched lazily page by page, and a key may be yielded more than once.
+	// If fetching fails or ctx is done, the error is yielded with an empty key and iteration stops
+	Scan(ctx context.Context, match string) iter.Seq2[string, error]
+}
+
+// Toucher defines the interface for cache implementations that can extend the TTL of a key without rewriting its value
+type Toucher interface {
+	// Touch resets the time-to-live of a key to ttl
+	// Returns ErrCacheMiss if the key is not found
+	Touch(ctx context.Context, key string, ttl time.Duration) error
+}
+
+// Clearer defines the interface for cache implementations that can remove all items
+type Clearer interface {
+	// Clear removes all items from cache
+	Clear(ctx context.Context) error
+}
+
+// SetNXer defines the interface for cache implementations that support set-if-not-exists
+type SetNXer[V any] interface {
+	// SetNX stores a value with a TTL only if the key does not exist
+	// Returns true if the value was stored and false if the key already existed
+	SetNX(ctx context.Context, key string, value V, ttl time.Duration) (bool, error)
+}
+
+// SetIfPresenter defines the interface for cache implementations that support set-if-exists
+type SetIfPresenter[V any] interface {
+	// SetIfPresent stores a value with a TTL only if the key already exists
+	// Returns true if the value was stored and false if the key did not exist,
+	// so keys that expired or were evicted are not resurrected
+	SetIfPresent(ctx context.Context, key string, value V, ttl time.Duration) (bool, error)
+}
+
+// Counter defines the interface for cache implementations that support atomic counters
+type Counter interface {
+	// Increment atomically adds delta to the counter stored at key and returns the new value
+	// A missing key starts at 0 and is given the TTL; the TTL of an existing key is not changed
+	Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// Decrement atomically subtracts delta from the counter stored at key and returns the new value
+	// A missing key starts at 0 and is given the TTL; the TTL of an existing key is not changed
+	Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+}
+
+// PrefixDeleter defines the interface for cache implementations that can delete keys by prefix
+type PrefixDeleter interface {
+	// DeleteByPrefix removes all keys starting with prefix and returns the number of keys deleted
+	DeleteByPrefix(ctx context.Context, prefix string) (int64, error)
+}
+
+// Locker defines the interface for cache implementations that provide a distributed lock
+type Locker interface {
+	// Lock tries once to acquire the lock named key for ttl, without waiting
+	// If acquired, unlock releases the lock and returns ErrLockNotHeld if it had already expired.
+	// If the lock is held by another owner, acquired is false and err is nil
+	Lock(ctx context.Context, key string, ttl time.Duration) (unlock func() error, acquired bool, err error)
+}
+
+// TTLBatchSetter defines the interface for cache implementations that can store a batch with per-item TTLs
+type TTLBatchSetter[V any] interface {
+	// BatchSetWithTTLs stores multiple values in cache, each with the TTL from ttls
+	// Keys missing from ttls are stored without expiry
+	BatchSetWithTTLs(ctx context.Context, items map[string]V, ttls map[string]time.Duration) error
+}
+
+// BytesCacher defines the interface for cache implementations that can read and write raw encoded bytes,
+// bypassing the Coder (e.g., a proxy passing through bytes received from upstream)
+type BytesCacher interface {
+	// GetBytes retrieves the raw stored bytes of a key
+	// Returns ErrCacheMiss if the key is not found
+	GetBytes(ctx context.Context, key string) ([]byte, error)
+
+	// SetBytes stores raw bytes verbatim with a TTL
+	// A TTL of zero or less means no expiry
+	SetBytes(ctx context.Context, key string, data []byte, ttl time.Duration) error
+}
+
+// Pinger defines the interface for cache implementations that can check their backend is reachable
+type Pinger interface {
+	// Ping returns an error if the cache backend is unreachable
+	// Implementations should return an error matching ErrCacheUnavailable when the backend is at fault,
+	// and one matching ctx.Err() when the caller's context ends first
+	Ping(ctx context.Context) error
+}
+
+// GetOrDefault retrieves a value from a cache, returning def on a miss
+// Unlike a compute function, nothing is stored on a miss. Errors other than ErrCacheMiss are returned as-is
+func GetOrDefault[V any](ctx context.Context, c Cacher[V], key string, def V) (V, error) {
+	value, err := c.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrCacheMiss) {
+			return def, nil
+		}
+		return value, err
+	}
+	return value, nil
 }
 
-// Deprecated: Use Cacher instead
 // LocalCacher defines the interface for local cache implementations with generic type support
+//
+// Deprecated: Use Cacher instead
 type LocalCacher[V any] interface {
 	Cacher[V]
 }
 
-// Deprecated: Use Cacher instead
 // RemoteCacher defines the interface for remote cache implementations with generic type support
+//
+// Deprecated: Use Cacher instead
 type RemoteCacher[V any] interface {
 	Cacher[V]
 }
 
-// Deprecated: Use BatchCacher instead
 // BatchLocalCacher defines the interface for local cache implementations that support batch operations
+//
+// Deprecated: Use BatchCacher instead
 type BatchLocalCacher[V any] interface {
 	BatchCacher[V]
 }
 
-// Deprecated: Use BatchCacher instead
 // BatchRemoteCacher defines the interface for remote cache implementations that support batch operations
+//
+// Deprecated: Use BatchCacher instead
 type BatchRemoteCacher[V any] interface {
 	BatchCacher[V]
 }
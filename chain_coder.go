@@ -0,0 +1,47 @@
+package cache
+
+// ChainCoder implements Coder by composing an inner value Coder with an ordered list of ByteTransforms
+// Encode applies the transforms in order after the inner Coder (e.g., msgpack → compress → encrypt),
+// and Decode inverts them in reverse order before the inner Coder
+type ChainCoder[V any] struct {
+	inner      Coder[V]
+	transforms []ByteTransform
+}
+
+// NewChainCoder creates a new ChainCoder instance
+// If inner is nil, JSONCoder is used
+func NewChainCoder[V any](inner Coder[V], transforms ...ByteTransform) *ChainCoder[V] {
+	if inner == nil {
+		inner = NewJSONCoder[V]()
+	}
+	return &ChainCoder[V]{
+		inner:      inner,
+		transforms: transforms,
+	}
+}
+
+// Encode serializes a value with the inner Coder and applies the transforms in order
+func (c *ChainCoder[V]) Encode(value V) ([]byte, error) {
+	data, err := c.inner.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range c.transforms {
+		if data, err = t.Transform(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// Decode inverts the transforms in reverse order and deserializes the result with the inner Coder
+func (c *ChainCoder[V]) Decode(data []byte) (V, error) {
+	var err error
+	for i := len(c.transforms) - 1; i >= 0; i-- {
+		if data, err = c.transforms[i].Invert(data); err != nil {
+			var zero V
+			return zero, err
+		}
+	}
+	return c.inner.Decode(data)
+}
@@ -0,0 +1,278 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// errCircuitOpenWrite is returned for writes skipped while the breaker is open
+// It matches ErrSetDropped so tiered caches treat the skipped write as best-effort
+var errCircuitOpenWrite = &kindError{kind: ErrSetDropped, err: ErrCircuitOpen}
+
+// CircuitState represents the state of a CircuitBreakerCacher
+type CircuitState int
+
+const (
+	// CircuitClosed means operations are passed through to the wrapped cache
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means operations fail fast without reaching the wrapped cache
+	CircuitOpen
+	// CircuitHalfOpen means a single probe operation is allowed through to test recovery
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerCacher wraps a Cacher and fails fast while the wrapped cache is unhealthy
+// The breaker opens after a number of consecutive failures. While open, reads return ErrCacheMiss
+// immediately so callers fall through to the next tier or recompute. Writes are skipped and return an error
+// matching both ErrCircuitOpen and ErrSetDropped, which tiered caches treat as a best-effort miss. Deletes are
+// skipped and return ErrCircuitOpen, so callers know the invalidation did not happen and the old value may
+// be served again once the breaker closes.
+// After the cooldown, the breaker half-opens and lets one probe operation through: success closes it,
+// failure opens it again. ErrCacheMiss, ErrNotFound, context.Canceled and rejections of the call itself
+// (e.g., ErrValueTooLarge, ErrKeyTooLong, ErrReadOnly or ErrSetDropped) do not count as failures
+type CircuitBreakerCacher[V any] struct {
+	inner         Cacher[V]
+	threshold     int
+	cooldown      time.Duration
+	onStateChange func(from, to CircuitState)
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+}
+
+// CircuitBreakerConfig holds configuration for CircuitBreakerCacher
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int
+
+	// Cooldown is how long the breaker stays open before allowing a probe
+	Cooldown time.Duration
+
+	// OnStateChange is called when the breaker changes state (optional)
+	// It is called with the breaker's lock held, so it must not call back into the breaker
+	OnStateChange func(from, to CircuitState)
+}
+
+// DefaultCircuitBreakerConfig returns a default configuration
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureThreshold: 5,
+		Cooldown:         10 * time.Second,
+		OnStateChange:    nil,
+	}
+}
+
+// NewCircuitBreakerCacher creates a new CircuitBreakerCacher wrapping the given cache
+func NewCircuitBreakerCacher[V any](inner Cacher[V], config *CircuitBreakerConfig) *CircuitBreakerCacher[V] {
+	if config == nil {
+		config = DefaultCircuitBreakerConfig()
+	}
+	threshold := config.FailureThreshold
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreakerCacher[V]{
+		inner:         inner,
+		threshold:     threshold,
+		cooldown:      config.Cooldown,
+		onStateChange: config.OnStateChange,
+		state:         CircuitClosed,
+	}
+}
+
+// State returns the current state of the breaker
+func (cb *CircuitBreakerCacher[V]) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state
+}
+
+// Get retrieves a value from the wrapped cache
+// Returns ErrCacheMiss without calling the wrapped cache while the breaker is open
+func (cb *CircuitBreakerCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	var zero V
+	if !cb.allow() {
+		return zero, ErrCacheMiss
+	}
+	value, err := cb.inner.Get(ctx, key)
+	cb.record(err)
+	return value, err
+}
+
+// Set stores a value in the wrapped cache
+// The write is skipped while the breaker is open and an error matching ErrCircuitOpen and ErrSetDropped is returned
+func (cb *CircuitBreakerCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	if !cb.allow() {
+		return errCircuitOpenWrite
+	}
+	err := cb.inner.Set(ctx, key, value, ttl)
+	cb.record(err)
+	return err
+}
+
+// Delete removes a value from the wrapped cache
+// The delete is skipped while the breaker is open and ErrCircuitOpen is returned
+func (cb *CircuitBreakerCacher[V]) Delete(ctx context.Context, key string) error {
+	if !cb.allow() {
+		return ErrCircuitOpen
+	}
+	err := cb.inner.Delete(ctx, key)
+	cb.record(err)
+	return err
+}
+
+// BatchGet retrieves multiple values from the wrapped cache
+// Returns an empty map without calling the wrapped cache while the breaker is open
+func (cb *CircuitBreakerCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	if !cb.allow() {
+		return make(map[string]V), nil
+	}
+	results, err := batchGet(ctx, cb.inner, keys)
+	cb.record(err)
+	return results, err
+}
+
+// BatchSet stores multiple values in the wrapped cache
+// The write is skipped while the breaker is open and an error matching ErrCircuitOpen and ErrSetDropped is returned
+func (cb *CircuitBreakerCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	if !cb.allow() {
+		return errCircuitOpenWrite
+	}
+	err := batchSet(ctx, cb.inner, items, ttl)
+	cb.record(err)
+	return err
+}
+
+// BatchDelete removes multiple values from the wrapped cache
+// The delete is skipped while the breaker is open and ErrCircuitOpen is returned
+func (cb *CircuitBreakerCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	if !cb.allow() {
+		return ErrCircuitOpen
+	}
+	err := batchDelete(ctx, cb.inner, keys)
+	cb.record(err)
+	return err
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache
+// The write is skipped while the breaker is open like Set's
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (cb *CircuitBreakerCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	if !cb.allow() {
+		return errCircuitOpenWrite
+	}
+	err := forwardSetNotFound(ctx, cb.inner, key, ttl)
+	cb.record(err)
+	return err
+}
+
+// GetTTL returns the remaining time-to-live of a key in the wrapped cache
+// Returns ErrCacheMiss without calling the wrapped cache while the breaker is open
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (cb *CircuitBreakerCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	if !cb.allow() {
+		return 0, ErrCacheMiss
+	}
+	ttl, err := forwardGetTTL(ctx, cb.inner, key)
+	cb.record(err)
+	return ttl, err
+}
+
+// Ping checks the wrapped cache's backend
+// It bypasses the breaker in either direction: it is sent while the breaker is open, so health checks report
+// the backend's state rather than the breaker's, and its result does not change the breaker's state
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (cb *CircuitBreakerCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, cb.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (cb *CircuitBreakerCacher[V]) Close() error {
+	return forwardClose(cb.inner)
+}
+
+// allow reports whether an operation may call the wrapped cache
+// An open breaker whose cooldown has elapsed becomes half-open and allows a single probe
+func (cb *CircuitBreakerCacher[V]) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitClosed:
+		return true
+	case CircuitOpen:
+		if time.Since(cb.openedAt) < cb.cooldown {
+			return false
+		}
+		cb.setState(CircuitHalfOpen)
+		return true
+	default:
+		// A probe is already in flight
+		return false
+	}
+}
+
+// record updates the breaker with the result of an operation
+func (cb *CircuitBreakerCacher[V]) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if isCircuitFailure(err) {
+		cb.failures++
+		if cb.state == CircuitHalfOpen || (cb.state == CircuitClosed && cb.failures >= cb.threshold) {
+			cb.failures = 0
+			cb.openedAt = time.Now()
+			cb.setState(CircuitOpen)
+		}
+		return
+	}
+
+	cb.failures = 0
+	if cb.state == CircuitHalfOpen {
+		cb.setState(CircuitClosed)
+	}
+}
+
+// setState changes the state and notifies the OnStateChange callback
+// Callers must hold cb.mu
+func (cb *CircuitBreakerCacher[V]) setState(state CircuitState) {
+	if cb.state == state {
+		return
+	}
+	from := cb.state
+	cb.state = state
+	if cb.onStateChange != nil {
+		cb.onStateChange(from, state)
+	}
+}
+
+// isCircuitFailure reports whether err indicates an unhealthy cache
+// Rejections of the call itself, such as an oversized value, say nothing about the cache's health
+func isCircuitFailure(err error) bool {
+	return err != nil &&
+		!errors.Is(err, ErrCacheMiss) &&
+		!errors.Is(err, ErrNotFound) &&
+		!errors.Is(err, ErrNoExpiry) &&
+		!errors.Is(err, context.Canceled) &&
+		!isRejection(err)
+}
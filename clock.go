@@ -0,0 +1,40 @@
+package cache
+
+import "time"
+
+// Clock abstracts the current time and timers for TTL handling
+// Caches use the real clock by default; tests can inject a fake Clock to advance time instantly
+// and assert expiry deterministically instead of sleeping
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock implements Clock with the time package
+type realClock struct{}
+
+// Now returns time.Now()
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After returns time.After(d)
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// RealClock returns a Clock backed by the system clock
+func RealClock() Clock {
+	return realClock{}
+}
+
+// clockOrReal returns c, or the real clock if c is nil
+func clockOrReal(c Clock) Clock {
+	if c == nil {
+		return realClock{}
+	}
+	return c
+}
@@ -8,3 +8,23 @@ type Coder[V any] interface {
 	// Decode deserializes bytes to a value
 	Decode(data []byte) (V, error)
 }
+
+// ByteTransform defines the interface for reversible byte-to-byte transforms such as compression or encryption
+type ByteTransform interface {
+	// Transform converts encoded bytes (e.g., compresses them)
+	Transform(data []byte) ([]byte, error)
+
+	// Invert reverses Transform (e.g., decompresses the bytes)
+	Invert(data []byte) ([]byte, error)
+}
+
+// uncompressedMagic prefixes payloads a compression transform left uncompressed because they were below
+// its MinCompressBytes, so Invert can tell them apart from compressed data and from unmarked legacy data
+var uncompressedMagic = []byte{0xff, 'U'}
+
+// markUncompressed returns data prefixed with the uncompressed marker
+func markUncompressed(data []byte) []byte {
+	out := make([]byte, len(uncompressedMagic), len(uncompressedMagic)+len(data))
+	copy(out, uncompressedMagic)
+	return append(out, data...)
+}
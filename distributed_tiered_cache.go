@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DistributedTieredCache wraps a TieredCache and serializes computes across instances with a distributed lock
+// Singleflight only coalesces computes within a process; on a miss in every tier, DistributedTieredCache also
+// takes a lock named LockPrefix + key (e.g., RedisCache.Lock), so only one instance in the cluster computes
+// a given key at a time. Instances that do not get the lock poll the tiers until the owner has stored the value,
+// and serve it without computing. The lock holder checks the tiers again before computing, in case another
+// instance stored the value between the miss and the lock.
+//
+// The lock reduces duplicate computes but does not guarantee mutual exclusion: if a compute outlives LockTTL
+// the lock expires and another instance may compute the same key concurrently, and there is no fencing token
+// to reject the slower writer, so the last write wins. Choose LockTTL well above the expected compute time.
+// If the lock cannot be taken (e.g., the lock backend is down) or LockWaitTimeout passes, the instance
+// computes without the lock, trading duplicate computes for availability
+type DistributedTieredCache[V any] struct {
+	tiered      *TieredCache[V]
+	locker      Locker
+	lockPrefix  string
+	lockTTL     time.Duration
+	pollEvery   time.Duration
+	waitTimeout time.Duration
+	onLockErr   func(key string, err error)
+}
+
+// DistributedTieredCacheConfig holds configuration for DistributedTieredCache
+type DistributedTieredCacheConfig struct {
+	// LockPrefix is prepended to the cache key to form the lock key.
+	// It must keep lock keys apart from cache keys when the locker shares a keyspace with a tier.
+	LockPrefix string
+
+	// LockTTL is how long a lock is held at most before it expires (default: 30s if zero or negative).
+	// It bounds how long other instances wait for an owner that crashed mid-compute.
+	LockTTL time.Duration
+
+	// PollInterval is the wait time between checks of the tiers (and attempts to take the lock)
+	// while another instance holds the lock (default: 50ms if zero or negative).
+	PollInterval time.Duration
+
+	// LockWaitTimeout is how long an instance waits for another instance's compute before it
+	// computes without the lock. If zero or negative, LockTTL is used.
+	LockWaitTimeout time.Duration
+
+	// OnLockError is called when taking or releasing a lock fails (optional), e.g., to log it.
+	// Releasing fails with ErrLockNotHeld when the compute outlived LockTTL.
+	OnLockError func(key string, err error)
+
+	// Tiered is the configuration for the underlying TieredCache (optional)
+	Tiered *TieredCacheConfig
+}
+
+// DefaultDistributedTieredCacheConfig returns a default configuration
+func DefaultDistributedTieredCacheConfig() *DistributedTieredCacheConfig {
+	return &DistributedTieredCacheConfig{
+		LockPrefix:      "lock:",
+		LockTTL:         30 * time.Second,
+		PollInterval:    50 * time.Millisecond,
+		LockWaitTimeout: 0, // same as LockTTL
+		OnLockError:     nil,
+		Tiered:          nil,
+	}
+}
+
+// NewDistributedTieredCache creates a new DistributedTieredCache
+// locker provides the distributed lock (e.g., a RedisCache) and is not closed by Close
+// caches is a slice where caches[0] is L1 (fastest), caches[1] is L2, etc.; at least one tier
+// should be shared between instances so waiting instances can read the owner's value
+func NewDistributedTieredCache[V any](locker Locker, config *DistributedTieredCacheConfig, caches ...Cacher[V]) *DistributedTieredCache[V] {
+	defaults := DefaultDistributedTieredCacheConfig()
+	if config == nil {
+		config = defaults
+	}
+	lockTTL := config.LockTTL
+	if lockTTL <= 0 {
+		lockTTL = defaults.LockTTL
+	}
+	pollEvery := config.PollInterval
+	if pollEvery <= 0 {
+		pollEvery = defaults.PollInterval
+	}
+	waitTimeout := config.LockWaitTimeout
+	if waitTimeout <= 0 {
+		waitTimeout = lockTTL
+	}
+	return &DistributedTieredCache[V]{
+		tiered:      NewTieredCacheWithConfig(config.Tiered, caches...),
+		locker:      locker,
+		lockPrefix:  config.LockPrefix,
+		lockTTL:     lockTTL,
+		pollEvery:   pollEvery,
+		waitTimeout: waitTimeout,
+		onLockErr:   config.OnLockError,
+	}
+}
+
+// Get retrieves a value using the tiered caching strategy with compute function
+// On a miss, computeFn runs under the distributed lock of the key; see DistributedTieredCache
+// Errors are classified like TieredCache.Get's
+func (dc *DistributedTieredCache[V]) Get(ctx context.Context, key string, ttl time.Duration, computeFn ComputeFunc[V]) (V, error) {
+	return dc.tiered.Get(ctx, key, ttl, dc.lockedCompute(computeFn))
+}
+
+// Set stores a value in all cache tiers
+func (dc *DistributedTieredCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return dc.tiered.Set(ctx, key, value, ttl)
+}
+
+// Delete removes a key from all cache tiers
+func (dc *DistributedTieredCache[V]) Delete(ctx context.Context, key string) error {
+	return dc.tiered.Delete(ctx, key)
+}
+
+// DeleteMany removes multiple keys from all cache tiers
+func (dc *DistributedTieredCache[V]) DeleteMany(ctx context.Context, keys []string) error {
+	return dc.tiered.DeleteMany(ctx, keys)
+}
+
+// Close closes all cache tiers
+// The locker is not closed
+func (dc *DistributedTieredCache[V]) Close() error {
+	return dc.tiered.Close()
+}
+
+// lockedCompute wraps computeFn so it runs under the distributed lock of the key
+// While another instance holds the lock, the tiers are polled and a value stored by the owner is returned
+// instead of computing; TieredCache then writes it to the tiers like a computed value
+func (dc *DistributedTieredCache[V]) lockedCompute(computeFn ComputeFunc[V]) ComputeFunc[V] {
+	return func(ctx context.Context, key string) (V, error) {
+		var zero V
+		lockKey := dc.lockPrefix + key
+		deadline := dc.tiered.clock.Now().Add(dc.waitTimeout)
+
+		for {
+			unlock, acquired, err := dc.locker.Lock(ctx, lockKey, dc.lockTTL)
+			if err != nil {
+				dc.reportLockError(key, err)
+				return computeFn(ctx, key)
+			}
+			if acquired {
+				defer func() {
+					if err := unlock(); err != nil {
+						dc.reportLockError(key, err)
+					}
+				}()
+				// Another instance may have stored the value between the miss and the lock
+				if val, found, err := dc.peek(ctx, key); found || errors.Is(err, ErrNotFound) {
+					return val, err
+				}
+				return computeFn(ctx, key)
+			}
+
+			select {
+			case <-ctx.Done():
+				return zero, ctx.Err()
+			case <-dc.tiered.clock.After(dc.pollEvery):
+			}
+			if val, found, err := dc.peek(ctx, key); found || errors.Is(err, ErrNotFound) {
+				return val, err
+			}
+			if !dc.tiered.clock.Now().Before(deadline) {
+				return computeFn(ctx, key)
+			}
+		}
+	}
+}
+
+// peek reads a key from the tiers without computing or backfilling
+// A negative cache entry stored by another instance is returned as ErrNotFound. Tier read failures are
+// treated as misses, so the lock owner computes and waiters keep polling: peek runs inside the compute
+// function, where returning them would misreport a backend failure as ErrComputeFailed.
+// In FailOpen mode they are reported to OnTierError like any other tier read failure
+func (dc *DistributedTieredCache[V]) peek(ctx context.Context, key string) (V, bool, error) {
+	val, _, found, err := dc.tiered.getCache(ctx, key)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return val, false, nil
+	}
+	return val, found, err
+}
+
+// reportLockError passes a lock error to OnLockError if configured
+func (dc *DistributedTieredCache[V]) reportLockError(key string, err error) {
+	if dc.onLockErr != nil {
+		dc.onLockErr(key, err)
+	}
+}
@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// envelopeMagic prefixes envelope payloads so Decode can tell them apart from bare values
+var envelopeMagic = []byte{0xff, 'E'}
+
+// envelopeVersion is the current envelope format version
+// Version 1 layout: magic (2 bytes) | version (1 byte) | StoredAt (8 bytes, Unix nanoseconds) |
+// SoftTTL (8 bytes, nanoseconds) | value encoded with the inner Coder, all integers big-endian.
+// New fields must be added in a new version so entries written by older versions still decode
+const envelopeVersion = 1
+
+// envelopeHeaderSize is the size of the version 1 header including the magic
+const envelopeHeaderSize = 2 + 1 + 8 + 8
+
+// Envelope wraps a cached value with metadata for stale-while-revalidate and refresh-ahead
+// Storing the metadata with the value lets readers compute its age without an extra round trip
+type Envelope[V any] struct {
+	Value    V             `json:"value"`
+	StoredAt time.Time     `json:"stored_at"`
+	SoftTTL  time.Duration `json:"soft_ttl,omitempty"`
+}
+
+// NewEnvelope wraps a value stored at now with the given soft TTL
+func NewEnvelope[V any](value V, softTTL time.Duration, now time.Time) Envelope[V] {
+	return Envelope[V]{Value: value, StoredAt: now, SoftTTL: softTTL}
+}
+
+// Age returns how long ago the value was stored
+func (e Envelope[V]) Age(now time.Time) time.Duration {
+	return now.Sub(e.StoredAt)
+}
+
+// IsStale reports whether the value is older than its soft TTL
+// A value without a soft TTL is never stale
+func (e Envelope[V]) IsStale(now time.Time) bool {
+	return e.SoftTTL > 0 && e.Age(now) >= e.SoftTTL
+}
+
+// EnvelopeCoder implements Coder for Envelope by prefixing the value encoded with an inner Coder
+// with a versioned binary header holding the metadata
+// Payloads without the envelope marker are decoded as bare values with zero metadata,
+// so entries written before the envelope was introduced still decode
+type EnvelopeCoder[V any] struct {
+	inner Coder[V]
+}
+
+// NewEnvelopeCoder creates a new EnvelopeCoder instance wrapping the given Coder
+// If inner is nil, JSONCoder is used
+func NewEnvelopeCoder[V any](inner Coder[V]) *EnvelopeCoder[V] {
+	if inner == nil {
+		inner = NewJSONCoder[V]()
+	}
+	return &EnvelopeCoder[V]{
+		inner: inner,
+	}
+}
+
+// Encode serializes an envelope as the versioned header followed by the value encoded with the inner Coder
+func (c *EnvelopeCoder[V]) Encode(envelope Envelope[V]) ([]byte, error) {
+	data, err := c.inner.Encode(envelope.Value)
+	if err != nil {
+		return nil, err
+	}
+	var storedAt int64
+	if !envelope.StoredAt.IsZero() {
+		storedAt = envelope.StoredAt.UnixNano()
+	}
+	out := make([]byte, envelopeHeaderSize, envelopeHeaderSize+len(data))
+	copy(out, envelopeMagic)
+	out[2] = envelopeVersion
+	binary.BigEndian.PutUint64(out[3:11], uint64(storedAt))
+	binary.BigEndian.PutUint64(out[11:19], uint64(envelope.SoftTTL))
+	return append(out, data...), nil
+}
+
+// Decode deserializes an envelope
+// Returns an error for envelopes written with an unknown format version
+func (c *EnvelopeCoder[V]) Decode(data []byte) (Envelope[V], error) {
+	if !bytes.HasPrefix(data, envelopeMagic) {
+		value, err := c.inner.Decode(data)
+		return Envelope[V]{Value: value}, err
+	}
+	if len(data) < len(envelopeMagic)+1 {
+		return Envelope[V]{}, fmt.Errorf("envelope: truncated header")
+	}
+	if version := data[2]; version != envelopeVersion {
+		return Envelope[V]{}, fmt.Errorf("envelope: unsupported version %d", version)
+	}
+	if len(data) < envelopeHeaderSize {
+		return Envelope[V]{}, fmt.Errorf("envelope: truncated header")
+	}
+
+	var envelope Envelope[V]
+	if storedAt := int64(binary.BigEndian.Uint64(data[3:11])); storedAt != 0 {
+		envelope.StoredAt = time.Unix(0, storedAt)
+	}
+	envelope.SoftTTL = time.Duration(binary.BigEndian.Uint64(data[11:19]))
+
+	value, err := c.inner.Decode(data[envelopeHeaderSize:])
+	if err != nil {
+		return Envelope[V]{}, err
+	}
+	envelope.Value = value
+	return envelope, nil
+}
@@ -0,0 +1,231 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// etcdMaxTxnOps is the maximum number of operations per transaction, matching etcd's default --max-txn-ops
+const etcdMaxTxnOps = 128
+
+// EtcdCache implements the BatchCacher interface with etcd as a strongly consistent remote cache
+// It suits small, config-like values. Values are encoded with a Coder and stored under Prefix,
+// and TTLs are implemented with leases, which etcd expires with a granularity of one second.
+// Each Set with a TTL grants a new lease; a lease whose key was overwritten or deleted expires unused
+type EtcdCache[V any] struct {
+	client     *clientv3.Client
+	coder      Coder[V]
+	prefix     string
+	ownsClient bool
+	closeOnce  sync.Once
+}
+
+// EtcdCacheConfig holds configuration for EtcdCache
+type EtcdCacheConfig struct {
+	// Endpoints are the etcd server addresses (e.g., "localhost:2379")
+	Endpoints []string
+
+	// DialTimeout is the timeout for establishing the connection
+	DialTimeout time.Duration
+
+	// Prefix is prepended to every key to form the etcd key (e.g., "/cache/config/")
+	Prefix string
+}
+
+// DefaultEtcdCacheConfig returns a default configuration
+func DefaultEtcdCacheConfig() *EtcdCacheConfig {
+	return &EtcdCacheConfig{
+		Endpoints:   []string{"localhost:2379"},
+		DialTimeout: 5 * time.Second,
+		Prefix:      "/cache/",
+	}
+}
+
+// NewEtcdCache creates a new EtcdCache instance connected to the configured endpoints
+// If coder is nil, JSONCoder is used
+func NewEtcdCache[V any](config *EtcdCacheConfig, coder Coder[V]) (*EtcdCache[V], error) {
+	if config == nil {
+		config = DefaultEtcdCacheConfig()
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   config.Endpoints,
+		DialTimeout: config.DialTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	e := NewEtcdCacheWithClient(client, config, coder)
+	e.ownsClient = true
+	return e, nil
+}
+
+// NewEtcdCacheWithClient creates a new EtcdCache instance using an existing etcd client
+// The connection fields of config (Endpoints, DialTimeout) are ignored
+// The client is not owned by the cache: Close does not close it
+func NewEtcdCacheWithClient[V any](client *clientv3.Client, config *EtcdCacheConfig, coder Coder[V]) *EtcdCache[V] {
+	if config == nil {
+		config = DefaultEtcdCacheConfig()
+	}
+	if coder == nil {
+		coder = NewJSONCoder[V]()
+	}
+	return &EtcdCache[V]{
+		client:     client,
+		coder:      coder,
+		prefix:     config.Prefix,
+		ownsClient: false,
+	}
+}
+
+// Get retrieves a value from etcd
+// Returns ErrCacheMiss if the key is not found or its lease has expired
+func (e *EtcdCache[V]) Get(ctx context.Context, key string) (V, error) {
+	var zero V
+
+	resp, err := e.client.Get(ctx, e.prefix+key)
+	if err != nil {
+		return zero, err
+	}
+	if len(resp.Kvs) == 0 {
+		return zero, ErrCacheMiss
+	}
+	return e.coder.Decode(resp.Kvs[0].Value)
+}
+
+// Set stores a value in etcd, attached to a new lease of ttl (rounded up to whole seconds)
+// A TTL of zero or less means no expiry
+func (e *EtcdCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	data, err := e.coder.Encode(value)
+	if err != nil {
+		return err
+	}
+	opts, err := e.leaseOpts(ctx, ttl)
+	if err != nil {
+		return err
+	}
+	_, err = e.client.Put(ctx, e.prefix+key, string(data), opts...)
+	return err
+}
+
+// Delete removes a value from etcd
+// Returns ErrCacheMiss if the key is not found
+func (e *EtcdCache[V]) Delete(ctx context.Context, key string) error {
+	resp, err := e.client.Delete(ctx, e.prefix+key)
+	if err != nil {
+		return err
+	}
+	if resp.Deleted == 0 {
+		return ErrCacheMiss
+	}
+	return nil
+}
+
+// BatchGet retrieves multiple values from etcd with one transaction per 128 keys,
+// so the values of each transaction are read from the same revision
+// Returns a map of key-value pairs for found keys
+// Missing keys are simply not included in the returned map; a value that fails to decode fails the call
+func (e *EtcdCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	results := make(map[string]V, len(keys))
+	for start := 0; start < len(keys); start += etcdMaxTxnOps {
+		chunk := keys[start:min(start+etcdMaxTxnOps, len(keys))]
+		ops := make([]clientv3.Op, len(chunk))
+		for i, key := range chunk {
+			ops[i] = clientv3.OpGet(e.prefix + key)
+		}
+		resp, err := e.client.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return results, err
+		}
+		for i, r := range resp.Responses {
+			kvs := r.GetResponseRange().GetKvs()
+			if len(kvs) == 0 {
+				continue
+			}
+			value, err := e.coder.Decode(kvs[0].Value)
+			if err != nil {
+				return results, err
+			}
+			results[chunk[i]] = value
+		}
+	}
+	return results, nil
+}
+
+// BatchSet stores multiple values in etcd with one transaction per 128 items
+// All items share the same TTL and a single lease
+func (e *EtcdCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+	opts, err := e.leaseOpts(ctx, ttl)
+	if err != nil {
+		return err
+	}
+
+	ops := make([]clientv3.Op, 0, min(len(items), etcdMaxTxnOps))
+	for key, value := range items {
+		data, err := e.coder.Encode(value)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, clientv3.OpPut(e.prefix+key, string(data), opts...))
+		if len(ops) == etcdMaxTxnOps {
+			if _, err := e.client.Txn(ctx).Then(ops...).Commit(); err != nil {
+				return err
+			}
+			ops = ops[:0]
+		}
+	}
+	if len(ops) > 0 {
+		if _, err := e.client.Txn(ctx).Then(ops...).Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BatchDelete removes multiple values from etcd with one transaction per 128 keys
+// Missing keys are ignored
+func (e *EtcdCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	for start := 0; start < len(keys); start += etcdMaxTxnOps {
+		chunk := keys[start:min(start+etcdMaxTxnOps, len(keys))]
+		ops := make([]clientv3.Op, len(chunk))
+		for i, key := range chunk {
+			ops[i] = clientv3.OpDelete(e.prefix + key)
+		}
+		if _, err := e.client.Txn(ctx).Then(ops...).Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the etcd client
+// Clients injected via NewEtcdCacheWithClient are left open
+// Close is idempotent: only the first call closes the client, and later calls return nil
+func (e *EtcdCache[V]) Close() error {
+	var err error
+	e.closeOnce.Do(func() {
+		if e.ownsClient {
+			err = e.client.Close()
+		}
+	})
+	return err
+}
+
+// leaseOpts grants a lease for ttl, rounded up to whole seconds, and returns the put option attaching it
+// Returns no options if ttl is zero or less
+func (e *EtcdCache[V]) leaseOpts(ctx context.Context, ttl time.Duration) ([]clientv3.OpOption, error) {
+	if ttl <= 0 {
+		return nil, nil
+	}
+	seconds := int64((ttl + time.Second - 1) / time.Second)
+	lease, err := e.client.Grant(ctx, seconds)
+	if err != nil {
+		return nil, err
+	}
+	return []clientv3.OpOption{clientv3.WithLease(lease.ID)}, nil
+}
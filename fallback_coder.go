@@ -0,0 +1,48 @@
+package cache
+
+// FallbackCoder implements Coder with a primary Coder and fallback Coders for decoding
+// Encode always uses the primary Coder. Decode tries the primary Coder first and, if it fails,
+// each fallback in order, so entries written with a previous schema or encoding still decode
+// during a rollout instead of requiring the cache to be flushed
+type FallbackCoder[V any] struct {
+	primary   Coder[V]
+	fallbacks []Coder[V]
+}
+
+// NewFallbackCoder creates a new FallbackCoder instance
+// If primary is nil, JSONCoder is used. Nil fallbacks are skipped
+func NewFallbackCoder[V any](primary Coder[V], fallbacks ...Coder[V]) *FallbackCoder[V] {
+	if primary == nil {
+		primary = NewJSONCoder[V]()
+	}
+	validFallbacks := make([]Coder[V], 0, len(fallbacks))
+	for _, fallback := range fallbacks {
+		if fallback != nil {
+			validFallbacks = append(validFallbacks, fallback)
+		}
+	}
+	return &FallbackCoder[V]{
+		primary:   primary,
+		fallbacks: validFallbacks,
+	}
+}
+
+// Encode serializes a value with the primary Coder
+func (c *FallbackCoder[V]) Encode(value V) ([]byte, error) {
+	return c.primary.Encode(value)
+}
+
+// Decode deserializes data with the primary Coder, then with each fallback in order until one succeeds
+// If all Coders fail, the primary Coder's error is returned
+func (c *FallbackCoder[V]) Decode(data []byte) (V, error) {
+	value, err := c.primary.Decode(data)
+	if err == nil {
+		return value, nil
+	}
+	for _, fallback := range c.fallbacks {
+		if fallbackValue, fallbackErr := fallback.Decode(data); fallbackErr == nil {
+			return fallbackValue, nil
+		}
+	}
+	return value, err
+}
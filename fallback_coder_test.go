@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFallbackCoderDecodesDataOfPreviousCoder(t *testing.T) {
+	oldCoder := NewJSONCoder[benchRecord]()
+	newCoder := NewMessagePackCoder[benchRecord]()
+	want := newBenchRecord()
+
+	legacy, err := oldCoder.Encode(want)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if _, err := newCoder.Decode(legacy); err == nil {
+		t.Fatal("new coder decoded data of the old coder; the test needs incompatible coders")
+	}
+
+	coder := NewFallbackCoder[benchRecord](newCoder, nil, oldCoder)
+	got, err := coder.Decode(legacy)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode = %+v, want %+v", got, want)
+	}
+}
+
+func TestFallbackCoderEncodesWithPrimary(t *testing.T) {
+	primary := NewMessagePackCoder[benchRecord]()
+	coder := NewFallbackCoder[benchRecord](primary, NewJSONCoder[benchRecord]())
+	want := newBenchRecord()
+
+	data, err := coder.Encode(want)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := primary.Decode(data)
+	if err != nil {
+		t.Fatalf("primary Decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("primary Decode = %+v, want %+v", got, want)
+	}
+}
+
+func TestFallbackCoderReturnsPrimaryErrorWhenAllFail(t *testing.T) {
+	primary := NewMessagePackCoder[benchRecord]()
+	coder := NewFallbackCoder[benchRecord](primary, NewJSONCoder[benchRecord]())
+	garbage := []byte{0xc1} // never used in MessagePack and invalid JSON
+
+	_, wantErr := primary.Decode(garbage)
+	_, err := coder.Decode(garbage)
+	if err == nil || err.Error() != wantErr.Error() {
+		t.Errorf("Decode error = %v, want the primary error %v", err, wantErr)
+	}
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// forwardSetNotFound stores a negative cache entry in a cache
+// Returns ErrUnsupported if the cache does not implement NegativeCacher
+func forwardSetNotFound(ctx context.Context, c any, key string, ttl time.Duration) error {
+	nc, ok := c.(NegativeCacher)
+	if !ok {
+		return ErrUnsupported
+	}
+	return nc.SetNotFound(ctx, key, ttl)
+}
+
+// forwardGetTTL returns the remaining time-to-live of a key in a cache
+// Returns ErrUnsupported if the cache does not implement TTLer
+func forwardGetTTL(ctx context.Context, c any, key string) (time.Duration, error) {
+	ttler, ok := c.(TTLer)
+	if !ok {
+		return 0, ErrUnsupported
+	}
+	return ttler.GetTTL(ctx, key)
+}
+
+// forwardPing checks that the backend of a cache is reachable
+// Returns ErrUnsupported if the cache does not implement Pinger
+func forwardPing(ctx context.Context, c any) error {
+	pinger, ok := c.(Pinger)
+	if !ok {
+		return ErrUnsupported
+	}
+	return pinger.Ping(ctx)
+}
+
+// forwardClose closes a cache
+// Returns ErrUnsupported if the cache does not implement io.Closer
+func forwardClose(c any) error {
+	closer, ok := c.(io.Closer)
+	if !ok {
+		return ErrUnsupported
+	}
+	return closer.Close()
+}
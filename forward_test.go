@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// lifecycleSpy is a MapCache that also implements TTLer, Pinger and io.Closer and records the calls
+type lifecycleSpy struct {
+	*MapCache[string]
+	pings  int
+	closes int
+}
+
+func (s *lifecycleSpy) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return time.Minute, nil
+}
+
+func (s *lifecycleSpy) Ping(ctx context.Context) error {
+	s.pings++
+	return nil
+}
+
+func (s *lifecycleSpy) Close() error {
+	s.closes++
+	return nil
+}
+
+func TestDecoratorsForwardGetTTLPingAndClose(t *testing.T) {
+	ctx := context.Background()
+	decorators := []struct {
+		name string
+		wrap func(inner Cacher[string]) Cacher[string]
+	}{
+		{"JitterCacher", func(inner Cacher[string]) Cacher[string] { return NewJitterCacher(inner, nil) }},
+		{"TTLClampCacher", func(inner Cacher[string]) Cacher[string] { return NewTTLClampCacher(inner, nil) }},
+		{"TimeoutCacher", func(inner Cacher[string]) Cacher[string] { return NewTimeoutCacher(inner, nil) }},
+		{"ReadOnlyCacher", func(inner Cacher[string]) Cacher[string] { return NewReadOnlyCacher(inner, nil) }},
+	}
+	for _, d := range decorators {
+		t.Run(d.name, func(t *testing.T) {
+			spy := &lifecycleSpy{MapCache: NewMapCache[string]()}
+			tier := d.wrap(spy)
+
+			ttler, ok := tier.(TTLer)
+			if !ok {
+				t.Fatal("decorator does not implement TTLer")
+			}
+			if ttl, err := ttler.GetTTL(ctx, "key"); err != nil || ttl != time.Minute {
+				t.Errorf("GetTTL = %s, %v; want %s, nil", ttl, err, time.Minute)
+			}
+
+			// A TieredCache only reaches the backend through the decorator
+			tc := NewTieredCache[string](tier)
+			if err := tc.HealthCheck(ctx); err != nil || spy.pings != 1 {
+				t.Errorf("HealthCheck = %v with %d pings, want nil with 1 ping", err, spy.pings)
+			}
+			if err := tc.Close(); err != nil || spy.closes != 1 {
+				t.Errorf("Close = %v with %d closes, want nil with 1 close", err, spy.closes)
+			}
+		})
+	}
+}
+
+// deadlineSpy is a lifecycleSpy that records whether GetTTL and Ping received a context with a deadline
+type deadlineSpy struct {
+	lifecycleSpy
+	ttlDeadline  bool
+	pingDeadline bool
+}
+
+func (s *deadlineSpy) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	_, s.ttlDeadline = ctx.Deadline()
+	return s.lifecycleSpy.GetTTL(ctx, key)
+}
+
+func (s *deadlineSpy) Ping(ctx context.Context) error {
+	_, s.pingDeadline = ctx.Deadline()
+	return s.lifecycleSpy.Ping(ctx)
+}
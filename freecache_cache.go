@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coocood/freecache"
+)
+
+// FreeCache wraps freecache to implement the BatchCacher interface with generic type support
+// freecache stores encoded values in a preallocated ring buffer, so its memory footprint is fixed
+// and it adds almost no GC overhead even with a very high number of entries.
+// When the buffer is full, the oldest entries are overwritten
+type FreeCache[V any] struct {
+	cache *freecache.Cache
+	coder Coder[V]
+}
+
+// FreeCacheConfig holds configuration for FreeCache
+type FreeCacheConfig struct {
+	// Size is the size of the ring buffer in bytes, allocated upfront.
+	// freecache enforces a minimum of 512KB. A single entry (key, value and header)
+	// cannot be larger than 1/1024 of Size.
+	Size int
+}
+
+// DefaultFreeCacheConfig returns a default configuration
+func DefaultFreeCacheConfig() *FreeCacheConfig {
+	return &FreeCacheConfig{
+		Size: 100 << 20, // 100MB
+	}
+}
+
+// NewFreeCache creates a new FreeCache instance
+// If coder is nil, JSONCoder is used
+func NewFreeCache[V any](config *FreeCacheConfig, coder Coder[V]) *FreeCache[V] {
+	if config == nil {
+		config = DefaultFreeCacheConfig()
+	}
+	if coder == nil {
+		coder = NewJSONCoder[V]()
+	}
+	return &FreeCache[V]{
+		cache: freecache.NewCache(config.Size),
+		coder: coder,
+	}
+}
+
+// Get retrieves a value from the cache
+func (f *FreeCache[V]) Get(ctx context.Context, key string) (V, error) {
+	var zero V
+	data, err := f.cache.Get([]byte(key))
+	if err != nil {
+		if errors.Is(err, freecache.ErrNotFound) {
+			return zero, ErrCacheMiss
+		}
+		return zero, err
+	}
+	return f.coder.Decode(data)
+}
+
+// Set stores a value in the cache with a TTL
+// freecache expires entries with second precision, so the TTL is rounded up to whole seconds
+// Returns ErrValueTooLarge if the entry exceeds 1/1024 of the buffer size
+func (f *FreeCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	data, err := f.coder.Encode(value)
+	if err != nil {
+		return err
+	}
+	if err := f.cache.Set([]byte(key), data, expireSeconds(ttl)); err != nil {
+		if errors.Is(err, freecache.ErrLargeEntry) || errors.Is(err, freecache.ErrLargeKey) {
+			return fmt.Errorf("%w: key %q: %v", ErrValueTooLarge, key, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetTTL returns the remaining time-to-live of a key with second precision
+// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+func (f *FreeCache[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := f.cache.TTL([]byte(key))
+	if err != nil {
+		if errors.Is(err, freecache.ErrNotFound) {
+			return 0, ErrCacheMiss
+		}
+		return 0, err
+	}
+	if ttl == 0 {
+		return 0, ErrNoExpiry
+	}
+	return time.Duration(ttl) * time.Second, nil
+}
+
+// Touch resets the TTL of a key without rewriting its value
+// The TTL is rounded up to whole seconds
+// Returns ErrCacheMiss if the key is not found
+func (f *FreeCache[V]) Touch(ctx context.Context, key string, ttl time.Duration) error {
+	if err := f.cache.Touch([]byte(key), expireSeconds(ttl)); err != nil {
+		if errors.Is(err, freecache.ErrNotFound) {
+			return ErrCacheMiss
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes a value from the cache
+// Returns ErrCacheMiss if the key is not found
+func (f *FreeCache[V]) Delete(ctx context.Context, key string) error {
+	if !f.cache.Del([]byte(key)) {
+		return ErrCacheMiss
+	}
+	return nil
+}
+
+// BatchGet retrieves multiple values from the cache
+// Returns a map of key-value pairs for found keys
+// Missing keys and keys that fail to decode are not included in the returned map
+func (f *FreeCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	results := make(map[string]V, len(keys))
+	for _, key := range keys {
+		data, err := f.cache.Get([]byte(key))
+		if err != nil {
+			continue
+		}
+		value, err := f.coder.Decode(data)
+		if err != nil {
+			continue
+		}
+		results[key] = value
+	}
+	return results, nil
+}
+
+// BatchSet stores multiple values in the cache with a TTL
+// All items share the same TTL
+func (f *FreeCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	for key, value := range items {
+		if err := f.Set(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BatchDelete removes multiple values from the cache
+// Missing keys are ignored
+func (f *FreeCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		f.cache.Del([]byte(key))
+	}
+	return nil
+}
+
+// Len returns the number of items in the cache
+func (f *FreeCache[V]) Len() int64 {
+	return f.cache.EntryCount()
+}
+
+// Clear removes all items from the cache
+func (f *FreeCache[V]) Clear(ctx context.Context) error {
+	f.cache.Clear()
+	return nil
+}
+
+// expireSeconds converts a TTL to freecache's expiry in seconds
+// Positive TTLs below one second are rounded up so they do not become "no expiry" (0)
+func expireSeconds(ttl time.Duration) int {
+	if ttl <= 0 {
+		return 0
+	}
+	return int((ttl + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// KeyHashFunc maps a cache key to a fixed-length string
+type KeyHashFunc func(key string) string
+
+// SHA256KeyHash hashes a key with SHA-256 and returns it as 64 hex characters
+// Collisions are practically impossible, at the cost of speed
+func SHA256KeyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// XXHashKeyHash hashes a key with 64-bit xxHash and returns it as 16 hex characters
+// Much faster than SHA-256, but not collision resistant against adversarial keys
+func XXHashKeyHash(key string) string {
+	return strconv.FormatUint(xxhash.Sum64String(key), 16)
+}
+
+// HashingCacher wraps a Cacher and replaces every key with a fixed-length hash before delegating
+// This keeps long keys (e.g., URLs) from bloating the backend's memory
+// Returned maps are keyed by the caller's original keys
+type HashingCacher[V any] struct {
+	inner  Cacher[V]
+	hash   KeyHashFunc
+	prefix string
+}
+
+// HashingConfig holds configuration for HashingCacher
+type HashingConfig struct {
+	// Hash maps keys to fixed-length strings.
+	// Use SHA256KeyHash for collision resistance or XXHashKeyHash for speed.
+	Hash KeyHashFunc
+
+	// Prefix is prepended as-is to every hashed key, keeping keys human-readable (e.g., "page:")
+	Prefix string
+}
+
+// DefaultHashingConfig returns a default configuration
+func DefaultHashingConfig() *HashingConfig {
+	return &HashingConfig{
+		Hash:   SHA256KeyHash,
+		Prefix: "",
+	}
+}
+
+// NewHashingCacher creates a new HashingCacher wrapping the given cache
+// If config is nil, DefaultHashingConfig is used
+func NewHashingCacher[V any](inner Cacher[V], config *HashingConfig) *HashingCacher[V] {
+	if config == nil {
+		config = DefaultHashingConfig()
+	}
+	hash := config.Hash
+	if hash == nil {
+		hash = SHA256KeyHash
+	}
+	return &HashingCacher[V]{
+		inner:  inner,
+		hash:   hash,
+		prefix: config.Prefix,
+	}
+}
+
+// Get retrieves a value from the wrapped cache using the hashed key
+func (h *HashingCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	return h.inner.Get(ctx, h.hashKey(key))
+}
+
+// Set stores a value in the wrapped cache using the hashed key
+func (h *HashingCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return h.inner.Set(ctx, h.hashKey(key), value, ttl)
+}
+
+// Delete removes a value from the wrapped cache using the hashed key
+func (h *HashingCacher[V]) Delete(ctx context.Context, key string) error {
+	return h.inner.Delete(ctx, h.hashKey(key))
+}
+
+// BatchGet retrieves multiple values from the wrapped cache using hashed keys
+// The returned map is keyed by the original keys
+func (h *HashingCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	hashed := make([]string, len(keys))
+	for i, key := range keys {
+		hashed[i] = h.hashKey(key)
+	}
+
+	found, err := batchGet(ctx, h.inner, hashed)
+	results := make(map[string]V, len(found))
+	for i, key := range hashed {
+		if value, ok := found[key]; ok {
+			results[keys[i]] = value
+		}
+	}
+	return results, err
+}
+
+// BatchSet stores multiple values in the wrapped cache using hashed keys
+func (h *HashingCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	hashed := make(map[string]V, len(items))
+	for key, value := range items {
+		hashed[h.hashKey(key)] = value
+	}
+	return batchSet(ctx, h.inner, hashed, ttl)
+}
+
+// BatchDelete removes multiple values from the wrapped cache using hashed keys
+func (h *HashingCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	hashed := make([]string, len(keys))
+	for i, key := range keys {
+		hashed[i] = h.hashKey(key)
+	}
+	return batchDelete(ctx, h.inner, hashed)
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache using the hashed key
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (h *HashingCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	return forwardSetNotFound(ctx, h.inner, h.hashKey(key), ttl)
+}
+
+// GetTTL returns the remaining time-to-live of the hashed key in the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (h *HashingCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return forwardGetTTL(ctx, h.inner, h.hashKey(key))
+}
+
+// Ping checks the wrapped cache's backend
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (h *HashingCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, h.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (h *HashingCacher[V]) Close() error {
+	return forwardClose(h.inner)
+}
+
+// hashKey returns the key used in the wrapped cache
+func (h *HashingCacher[V]) hashKey(key string) string {
+	return h.prefix + h.hash(key)
+}
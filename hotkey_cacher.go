@@ -0,0 +1,260 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// KeyCount is an approximate access count of a key
+type KeyCount struct {
+	Key   string
+	Count uint64
+}
+
+// HotkeyCacher wraps a Cacher and tracks approximate per-key read frequency to detect hotkeys
+// Every key read by Get or BatchGet is counted in a count-min sketch, whose memory is fixed
+// regardless of the number of distinct keys, and the most frequent keys are kept as candidates for TopKeys.
+// Counts may be overestimated (never underestimated) due to hash collisions
+type HotkeyCacher[V any] struct {
+	inner Cacher[V]
+
+	mu       sync.Mutex
+	sketch   *countMinSketch
+	top      map[string]uint64
+	capacity int
+	minKey   string
+}
+
+// HotkeyConfig holds configuration for HotkeyCacher
+type HotkeyConfig struct {
+	// Width is the number of counters per row of the count-min sketch.
+	// Wider sketches overestimate less.
+	Width int
+
+	// Depth is the number of rows (hash functions) of the count-min sketch.
+	// Deeper sketches overestimate less often.
+	Depth int
+
+	// Capacity is the maximum number of keys tracked for TopKeys
+	Capacity int
+}
+
+// DefaultHotkeyConfig returns a default configuration
+func DefaultHotkeyConfig() *HotkeyConfig {
+	return &HotkeyConfig{
+		Width:    4096,
+		Depth:    4,
+		Capacity: 100,
+	}
+}
+
+// NewHotkeyCacher creates a new HotkeyCacher wrapping the given cache
+// If config is nil, DefaultHotkeyConfig is used
+func NewHotkeyCacher[V any](inner Cacher[V], config *HotkeyConfig) *HotkeyCacher[V] {
+	if config == nil {
+		config = DefaultHotkeyConfig()
+	}
+	defaults := DefaultHotkeyConfig()
+	width, depth, capacity := config.Width, config.Depth, config.Capacity
+	if width <= 0 {
+		width = defaults.Width
+	}
+	if depth <= 0 {
+		depth = defaults.Depth
+	}
+	if capacity <= 0 {
+		capacity = defaults.Capacity
+	}
+	return &HotkeyCacher[V]{
+		inner:    inner,
+		sketch:   newCountMinSketch(width, depth),
+		top:      make(map[string]uint64, capacity+1),
+		capacity: capacity,
+	}
+}
+
+// Get retrieves a value from the wrapped cache and counts the access
+func (h *HotkeyCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	h.record(key)
+	return h.inner.Get(ctx, key)
+}
+
+// Set stores a value in the wrapped cache
+func (h *HotkeyCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return h.inner.Set(ctx, key, value, ttl)
+}
+
+// Delete removes a value from the wrapped cache
+func (h *HotkeyCacher[V]) Delete(ctx context.Context, key string) error {
+	return h.inner.Delete(ctx, key)
+}
+
+// BatchGet retrieves multiple values from the wrapped cache and counts the access to each key
+func (h *HotkeyCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	for _, key := range keys {
+		h.record(key)
+	}
+	return batchGet(ctx, h.inner, keys)
+}
+
+// BatchSet stores multiple values in the wrapped cache
+func (h *HotkeyCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	return batchSet(ctx, h.inner, items, ttl)
+}
+
+// BatchDelete removes multiple values from the wrapped cache
+func (h *HotkeyCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	return batchDelete(ctx, h.inner, keys)
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (h *HotkeyCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	return forwardSetNotFound(ctx, h.inner, key, ttl)
+}
+
+// GetTTL returns the remaining time-to-live of a key in the wrapped cache
+// It is not counted as an access
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (h *HotkeyCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return forwardGetTTL(ctx, h.inner, key)
+}
+
+// Ping checks the wrapped cache's backend
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (h *HotkeyCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, h.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (h *HotkeyCacher[V]) Close() error {
+	return forwardClose(h.inner)
+}
+
+// TopKeys returns up to n of the most frequently read keys with their approximate counts, most frequent first
+// Only the keys tracked as candidates (up to Capacity) are considered
+func (h *HotkeyCacher[V]) TopKeys(n int) []KeyCount {
+	h.mu.Lock()
+	counts := make([]KeyCount, 0, len(h.top))
+	for key, count := range h.top {
+		counts = append(counts, KeyCount{Key: key, Count: count})
+	}
+	h.mu.Unlock()
+
+	slices.SortFunc(counts, func(a, b KeyCount) int {
+		if a.Count != b.Count {
+			if a.Count > b.Count {
+				return -1
+			}
+			return 1
+		}
+		if a.Key < b.Key {
+			return -1
+		}
+		if a.Key > b.Key {
+			return 1
+		}
+		return 0
+	})
+	if n >= 0 && n < len(counts) {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// Reset clears all counts, e.g., at the start of each observation window
+func (h *HotkeyCacher[V]) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sketch.reset()
+	clear(h.top)
+	h.minKey = ""
+}
+
+// record counts an access to key and updates the top key candidates
+func (h *HotkeyCacher[V]) record(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	count := h.sketch.increment(key)
+	if _, ok := h.top[key]; ok {
+		h.top[key] = count
+		if key == h.minKey {
+			h.updateMin()
+		}
+		return
+	}
+	if len(h.top) < h.capacity {
+		h.top[key] = count
+		if len(h.top) == 1 || count < h.top[h.minKey] {
+			h.minKey = key
+		}
+		return
+	}
+	if count > h.top[h.minKey] {
+		delete(h.top, h.minKey)
+		h.top[key] = count
+		h.updateMin()
+	}
+}
+
+// updateMin finds the least frequent top key candidate
+// Callers must hold h.mu
+func (h *HotkeyCacher[V]) updateMin() {
+	first := true
+	var minCount uint64
+	for key, count := range h.top {
+		if first || count < minCount {
+			h.minKey, minCount = key, count
+			first = false
+		}
+	}
+}
+
+// countMinSketch estimates frequencies with fixed memory
+// Each row is indexed by a different hash of the key, and the estimate is the minimum counter across rows
+type countMinSketch struct {
+	width    uint64
+	counters [][]uint64
+}
+
+// newCountMinSketch creates a sketch with depth rows of width counters
+func newCountMinSketch(width, depth int) *countMinSketch {
+	counters := make([][]uint64, depth)
+	for i := range counters {
+		counters[i] = make([]uint64, width)
+	}
+	return &countMinSketch{
+		width:    uint64(width),
+		counters: counters,
+	}
+}
+
+// increment adds one to the counters of key and returns its new estimated count
+// Row indexes are derived from a single 64-bit hash with double hashing
+func (s *countMinSketch) increment(key string) uint64 {
+	sum := xxhash.Sum64String(key)
+	h1, h2 := sum&0xffffffff, (sum>>32)|1
+	var estimate uint64
+	for i, row := range s.counters {
+		idx := (h1 + uint64(i)*h2) % s.width
+		row[idx]++
+		if i == 0 || row[idx] < estimate {
+			estimate = row[idx]
+		}
+	}
+	return estimate
+}
+
+// reset sets all counters to zero
+func (s *countMinSketch) reset() {
+	for _, row := range s.counters {
+		clear(row)
+	}
+}
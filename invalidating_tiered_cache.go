@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// InvalidatingTieredCache wraps a TieredCache and keeps local tiers coherent across instances
+// Set and Delete publish the key to a Redis Pub/Sub channel, and every instance subscribed to the
+// channel evicts the key from its local tiers (e.g., Ristretto L1) so the next Get reads the fresh value
+// from the shared tiers. Invalidations published while an instance is disconnected are not replayed,
+// so local tiers should keep a short TTL as a safety net.
+//
+// Publishing is best-effort: it happens after the local write or removal, and a failure to publish
+// (e.g., the broker is down) is reported to OnPublishError instead of failing the call.
+// Delivery to connected subscribers is at-least-once per call: the same key may be invalidated again
+// by later or concurrent calls, and evicting a key twice is harmless. Between the local removal and
+// the moment another instance processes the message, that instance can still serve the old value
+// from its local tiers
+type InvalidatingTieredCache[V any] struct {
+	tiered     *TieredCache[V]
+	localTiers []Cacher[V]
+	client     *redis.Client
+	pubsub     *redis.PubSub
+	channel    string
+	instanceID string
+	backoff    time.Duration
+	onPubErr   func(err error)
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// InvalidatingTieredCacheConfig holds configuration for InvalidatingTieredCache
+type InvalidatingTieredCacheConfig struct {
+	// Channel is the Redis Pub/Sub channel used for invalidation messages
+	Channel string
+
+	// LocalTiers is the number of leading tiers that are local to the process (caches[0:LocalTiers]).
+	// Only these tiers are evicted when an invalidation message is received.
+	LocalTiers int
+
+	// ReconnectBackoff is the wait time before receiving again after the subscription fails
+	// (default: 1s if zero or negative). go-redis reconnects and resubscribes on the next receive.
+	ReconnectBackoff time.Duration
+
+	// OnPublishError is called when an invalidation message cannot be published (optional)
+	OnPublishError func(err error)
+
+	// Tiered is the configuration for the underlying TieredCache (optional)
+	Tiered *TieredCacheConfig
+}
+
+// DefaultInvalidatingTieredCacheConfig returns a default configuration
+func DefaultInvalidatingTieredCacheConfig() *InvalidatingTieredCacheConfig {
+	return &InvalidatingTieredCacheConfig{
+		Channel:          "cache:invalidate",
+		LocalTiers:       1,
+		ReconnectBackoff: time.Second,
+		OnPublishError:   nil,
+		Tiered:           nil,
+	}
+}
+
+// NewInvalidatingTieredCache creates a new InvalidatingTieredCache and starts the subscription in a background goroutine
+// client is used for publishing and subscribing, and is not closed by Close
+// caches is a slice where caches[0] is L1 (fastest), caches[1] is L2, etc.
+func NewInvalidatingTieredCache[V any](client *redis.Client, config *InvalidatingTieredCacheConfig, caches ...Cacher[V]) *InvalidatingTieredCache[V] {
+	defaults := DefaultInvalidatingTieredCacheConfig()
+	if config == nil {
+		config = defaults
+	}
+	tiered := NewTieredCacheWithConfig(config.Tiered, caches...)
+
+	backoff := config.ReconnectBackoff
+	if backoff <= 0 {
+		backoff = defaults.ReconnectBackoff
+	}
+
+	localTiers := config.LocalTiers
+	if localTiers > len(tiered.caches) {
+		localTiers = len(tiered.caches)
+	}
+	if localTiers < 0 {
+		localTiers = 0
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ic := &InvalidatingTieredCache[V]{
+		tiered:     tiered,
+		localTiers: tiered.caches[:localTiers],
+		client:     client,
+		pubsub:     client.Subscribe(ctx, config.Channel),
+		channel:    config.Channel,
+		instanceID: newInstanceID(),
+		backoff:    backoff,
+		onPubErr:   config.OnPublishError,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	go ic.subscribe(ctx)
+	return ic
+}
+
+// Get retrieves a value using the tiered caching strategy with compute function
+func (ic *InvalidatingTieredCache[V]) Get(ctx context.Context, key string, ttl time.Duration, computeFn ComputeFunc[V]) (V, error) {
+	return ic.tiered.Get(ctx, key, ttl, computeFn)
+}
+
+// Set stores a value in all cache tiers and notifies other instances to evict their local copy
+// Publishing is best-effort and does not affect the returned error
+func (ic *InvalidatingTieredCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	if err := ic.tiered.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	ic.publish(ctx, key)
+	return nil
+}
+
+// Delete removes a key from all cache tiers and notifies other instances to evict their local copy
+// The invalidation is published even if removal fails in some tier, since other instances
+// may still hold the key. Publishing is best-effort and does not affect the returned error
+func (ic *InvalidatingTieredCache[V]) Delete(ctx context.Context, key string) error {
+	err := ic.tiered.Delete(ctx, key)
+	ic.publish(ctx, key)
+	return err
+}
+
+// DeleteMany removes multiple keys from all cache tiers and notifies other instances to evict their local copies
+// Invalidation messages are published in a single pipeline, even if removal fails in some tier.
+// Publishing is best-effort and does not affect the returned error
+func (ic *InvalidatingTieredCache[V]) DeleteMany(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	err := ic.tiered.DeleteMany(ctx, keys)
+	ic.publish(ctx, keys...)
+	return err
+}
+
+// Close stops the subscription, waits for the background goroutine to exit, and closes all cache tiers
+// like TieredCache.Close. The Redis client is not closed
+func (ic *InvalidatingTieredCache[V]) Close() error {
+	ic.cancel()
+	err := ic.pubsub.Close()
+	<-ic.done
+	if tiersErr := ic.tiered.Close(); tiersErr != nil {
+		return newMultiError(err, tiersErr)
+	}
+	return err
+}
+
+// publish sends an invalidation message for each key, reporting failures to OnPublishError
+// Messages are formatted as "<instanceID>:<key>" so an instance can ignore its own messages
+func (ic *InvalidatingTieredCache[V]) publish(ctx context.Context, keys ...string) {
+	var err error
+	if len(keys) == 1 {
+		err = ic.client.Publish(ctx, ic.channel, ic.instanceID+":"+keys[0]).Err()
+	} else {
+		pipe := ic.client.Pipeline()
+		for _, key := range keys {
+			pipe.Publish(ctx, ic.channel, ic.instanceID+":"+key)
+		}
+		_, err = pipe.Exec(ctx)
+	}
+	if err != nil && ic.onPubErr != nil {
+		ic.onPubErr(err)
+	}
+}
+
+// subscribe receives invalidation messages until ctx is cancelled
+// On receive errors (e.g., a dropped connection) it waits for the backoff and tries again,
+// letting go-redis reconnect and resubscribe
+func (ic *InvalidatingTieredCache[V]) subscribe(ctx context.Context) {
+	defer close(ic.done)
+
+	for {
+		msg, err := ic.pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, redis.ErrClosed) {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ic.tiered.clock.After(ic.backoff):
+			}
+			continue
+		}
+
+		instanceID, key, ok := strings.Cut(msg.Payload, ":")
+		if !ok || instanceID == ic.instanceID {
+			continue
+		}
+		ic.evictLocal(ctx, key)
+	}
+}
+
+// evictLocal removes a key from the local tiers
+func (ic *InvalidatingTieredCache[V]) evictLocal(ctx context.Context, key string) {
+	for _, cache := range ic.localTiers {
+		_ = cache.Delete(ctx, key)
+	}
+}
+
+// newInstanceID returns a random identifier for this process's invalidation messages
+func newInstanceID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
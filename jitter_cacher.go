@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// jitterGroups is the number of TTL groups BatchSet splits items into
+// Each group is written with its own jittered TTL in a single BatchSet call
+const jitterGroups = 8
+
+// JitterCacher wraps a Cacher and adds random jitter to every TTL it writes
+// This spreads out expiry of keys written at the same time and prevents them from expiring simultaneously
+// To apply jitter to a TieredCache, wrap each tier before passing it to NewTieredCache
+type JitterCacher[V any] struct {
+	inner   Cacher[V]
+	percent float64
+}
+
+// JitterConfig holds configuration for JitterCacher
+type JitterConfig struct {
+	// Percent is the maximum jitter as a percentage of the TTL (e.g., 10 for ±10%).
+	// Values are clamped to the range [0, 100).
+	Percent float64
+}
+
+// DefaultJitterConfig returns a default configuration
+func DefaultJitterConfig() *JitterConfig {
+	return &JitterConfig{
+		Percent: 10,
+	}
+}
+
+// NewJitterCacher creates a new JitterCacher wrapping the given cache
+func NewJitterCacher[V any](inner Cacher[V], config *JitterConfig) *JitterCacher[V] {
+	if config == nil {
+		config = DefaultJitterConfig()
+	}
+	percent := config.Percent
+	if percent < 0 {
+		percent = 0
+	}
+	if percent >= 100 {
+		percent = 99
+	}
+	return &JitterCacher[V]{
+		inner:   inner,
+		percent: percent,
+	}
+}
+
+// Get retrieves a value from the wrapped cache
+func (j *JitterCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	return j.inner.Get(ctx, key)
+}
+
+// Set stores a value in the wrapped cache with a jittered TTL
+func (j *JitterCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return j.inner.Set(ctx, key, value, jitterTTL(ttl, j.percent))
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache with a jittered TTL
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (j *JitterCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	return forwardSetNotFound(ctx, j.inner, key, jitterTTL(ttl, j.percent))
+}
+
+// Delete removes a value from the wrapped cache
+func (j *JitterCacher[V]) Delete(ctx context.Context, key string) error {
+	return j.inner.Delete(ctx, key)
+}
+
+// BatchGet retrieves multiple values from the wrapped cache
+func (j *JitterCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	return batchGet(ctx, j.inner, keys)
+}
+
+// BatchSet stores multiple values in the wrapped cache with jittered TTLs
+// Items are split into a fixed number of groups, each written with its own jittered TTL,
+// so keys in the same batch do not all expire at once
+func (j *JitterCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if ttl <= 0 || j.percent == 0 {
+		return batchSet(ctx, j.inner, items, ttl)
+	}
+
+	groups := make([]map[string]V, jitterGroups)
+	i := 0
+	for key, value := range items {
+		g := i % jitterGroups
+		if groups[g] == nil {
+			groups[g] = make(map[string]V)
+		}
+		groups[g][key] = value
+		i++
+	}
+
+	dropped := false
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		if err := batchSet(ctx, j.inner, group, jitterTTL(ttl, j.percent)); err != nil {
+			if !errors.Is(err, ErrSetDropped) {
+				return err
+			}
+			dropped = true
+		}
+	}
+	if dropped {
+		return ErrSetDropped
+	}
+	return nil
+}
+
+// BatchDelete removes multiple values from the wrapped cache
+func (j *JitterCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	return batchDelete(ctx, j.inner, keys)
+}
+
+// GetTTL returns the remaining time-to-live of a key in the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (j *JitterCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return forwardGetTTL(ctx, j.inner, key)
+}
+
+// Ping checks the wrapped cache's backend
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (j *JitterCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, j.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (j *JitterCacher[V]) Close() error {
+	return forwardClose(j.inner)
+}
+
+// jitterTTL returns ttl adjusted by a random offset of up to ±percent%
+// Non-positive TTLs are returned unchanged, and a positive TTL never becomes zero or negative
+func jitterTTL(ttl time.Duration, percent float64) time.Duration {
+	if ttl <= 0 || percent <= 0 {
+		return ttl
+	}
+	delta := float64(ttl) * percent / 100
+	jittered := ttl + time.Duration((rand.Float64()*2-1)*delta)
+	if jittered <= 0 {
+		return ttl
+	}
+	return jittered
+}
@@ -1,23 +1,89 @@
 package cache
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
 
 // JSONCoder implements Coder using JSON encoding
-type JSONCoder[V any] struct{}
+type JSONCoder[V any] struct {
+	escapeHTML bool
+	useNumber  bool
+}
+
+// JSONOption configures a JSONCoder
+type JSONOption func(*jsonOptions)
+
+// jsonOptions holds the settings applied by JSONOption functions
+type jsonOptions struct {
+	escapeHTML bool
+	useNumber  bool
+}
+
+// WithJSONEscapeHTML sets whether <, > and & are escaped in JSON strings (default: true, as json.Marshal does)
+// Disable it to keep HTML special characters unescaped
+func WithJSONEscapeHTML(escape bool) JSONOption {
+	return func(o *jsonOptions) {
+		o.escapeHTML = escape
+	}
+}
+
+// WithJSONUseNumber decodes numbers into interface{} values as json.Number instead of float64
+// This keeps large integers from losing precision
+func WithJSONUseNumber() JSONOption {
+	return func(o *jsonOptions) {
+		o.useNumber = true
+	}
+}
 
 // NewJSONCoder creates a new JSONCoder instance
-func NewJSONCoder[V any]() *JSONCoder[V] {
-	return &JSONCoder[V]{}
+// Without options, it behaves like json.Marshal and json.Unmarshal
+func NewJSONCoder[V any](opts ...JSONOption) *JSONCoder[V] {
+	o := jsonOptions{
+		escapeHTML: true,
+		useNumber:  false,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &JSONCoder[V]{
+		escapeHTML: o.escapeHTML,
+		useNumber:  o.useNumber,
+	}
 }
 
 // Encode serializes a value to JSON bytes
 func (c *JSONCoder[V]) Encode(value V) ([]byte, error) {
-	return json.Marshal(value)
+	if c.escapeHTML {
+		return json.Marshal(value)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	// Encoder terminates each value with a newline, which json.Marshal does not
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
 }
 
 // Decode deserializes JSON bytes to a value
 func (c *JSONCoder[V]) Decode(data []byte) (V, error) {
 	var value V
-	err := json.Unmarshal(data, &value)
-	return value, err
+	if !c.useNumber {
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return value, err
+	}
+	// Reject trailing data like json.Unmarshal does
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return value, errors.New("json: invalid data after top-level value")
+	}
+	return value, nil
 }
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// KeyFunc converts a typed key into a string cache key
+// It must be deterministic and should be injective, since distinct keys mapping to the same string share an entry
+type KeyFunc[K any] func(key K) string
+
+// KeyedCache wraps a Cacher and accepts typed keys (e.g., composite struct keys) instead of strings
+// Keys are converted with a KeyFunc, and returned maps are keyed by the caller's original typed keys
+type KeyedCache[K comparable, V any] struct {
+	inner   Cacher[V]
+	keyFunc KeyFunc[K]
+}
+
+// NewKeyedCache creates a new KeyedCache wrapping the given cache
+// If keyFunc is nil, keys are formatted with fmt.Sprint, which suits strings, numbers and
+// structs of such fields but is not injective in general
+func NewKeyedCache[K comparable, V any](inner Cacher[V], keyFunc KeyFunc[K]) *KeyedCache[K, V] {
+	if keyFunc == nil {
+		keyFunc = func(key K) string { return fmt.Sprint(key) }
+	}
+	return &KeyedCache[K, V]{
+		inner:   inner,
+		keyFunc: keyFunc,
+	}
+}
+
+// Get retrieves a value from the wrapped cache using the converted key
+func (k *KeyedCache[K, V]) Get(ctx context.Context, key K) (V, error) {
+	return k.inner.Get(ctx, k.keyFunc(key))
+}
+
+// Set stores a value in the wrapped cache using the converted key
+func (k *KeyedCache[K, V]) Set(ctx context.Context, key K, value V, ttl time.Duration) error {
+	return k.inner.Set(ctx, k.keyFunc(key), value, ttl)
+}
+
+// Delete removes a value from the wrapped cache using the converted key
+func (k *KeyedCache[K, V]) Delete(ctx context.Context, key K) error {
+	return k.inner.Delete(ctx, k.keyFunc(key))
+}
+
+// BatchGet retrieves multiple values from the wrapped cache using converted keys
+// The returned map is keyed by the original typed keys
+func (k *KeyedCache[K, V]) BatchGet(ctx context.Context, keys []K) (map[K]V, error) {
+	converted := make([]string, len(keys))
+	for i, key := range keys {
+		converted[i] = k.keyFunc(key)
+	}
+
+	found, err := batchGet(ctx, k.inner, converted)
+	results := make(map[K]V, len(found))
+	for i, key := range converted {
+		if value, ok := found[key]; ok {
+			results[keys[i]] = value
+		}
+	}
+	return results, err
+}
+
+// BatchSet stores multiple values in the wrapped cache using converted keys
+func (k *KeyedCache[K, V]) BatchSet(ctx context.Context, items map[K]V, ttl time.Duration) error {
+	converted := make(map[string]V, len(items))
+	for key, value := range items {
+		converted[k.keyFunc(key)] = value
+	}
+	return batchSet(ctx, k.inner, converted, ttl)
+}
+
+// BatchDelete removes multiple values from the wrapped cache using converted keys
+func (k *KeyedCache[K, V]) BatchDelete(ctx context.Context, keys []K) error {
+	converted := make([]string, len(keys))
+	for i, key := range keys {
+		converted[i] = k.keyFunc(key)
+	}
+	return batchDelete(ctx, k.inner, converted)
+}
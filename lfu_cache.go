@@ -0,0 +1,308 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LFUCache implements the BatchCacher interface with an in-memory least-frequently-used cache
+// Items are kept in per-frequency lists, which are themselves kept in a list ordered by frequency, so
+// Get, Set, Delete and eviction are O(1) regardless of removals. When the cache is full,
+// the least frequently used item is evicted, and the least recently used one among equally frequent items.
+// Unlike Ristretto's TinyLFU approximation, frequencies are exact for the items in the cache,
+// which suits a stable hot set mixed with bursty one-off keys. Expired items are removed lazily when they are read
+type LFUCache[V any] struct {
+	mu         sync.Mutex
+	maxEntries int
+	items      map[string]*list.Element // key -> element in its bucket's entries
+	buckets    *list.List               // *lfuBucket, lowest frequency first
+	evictions  uint64
+	clock      Clock
+}
+
+// lfuEntry is the value stored in each element of a bucket's entries
+type lfuEntry[V any] struct {
+	key       string
+	value     V
+	bucket    *list.Element // element of the bucket holding the entry in LFUCache.buckets
+	expiresAt time.Time     // zero means no expiry
+}
+
+// lfuBucket holds the entries used a given number of times
+// Buckets only exist while they hold entries
+type lfuBucket struct {
+	freq    int
+	entries *list.List // most recently used first
+}
+
+// LFUCacheConfig holds configuration for LFUCache
+type LFUCacheConfig struct {
+	// MaxEntries is the maximum number of items in cache.
+	// When the cache is full, the least frequently used item is evicted.
+	MaxEntries int
+
+	// Clock is the time source for TTLs (default: the system clock).
+	Clock Clock
+}
+
+// DefaultLFUCacheConfig returns a default configuration
+func DefaultLFUCacheConfig() *LFUCacheConfig {
+	return &LFUCacheConfig{
+		MaxEntries: 10000,
+		Clock:      RealClock(),
+	}
+}
+
+// LFUStats holds statistics of an LFUCache
+type LFUStats struct {
+	// Size is the number of items in the cache, including expired items not yet removed
+	Size int
+
+	// Evictions is the number of items evicted because the cache was full
+	Evictions uint64
+}
+
+// NewLFUCache creates a new LFUCache instance
+func NewLFUCache[V any](config *LFUCacheConfig) *LFUCache[V] {
+	if config == nil {
+		config = DefaultLFUCacheConfig()
+	}
+	return &LFUCache[V]{
+		maxEntries: config.MaxEntries,
+		items:      make(map[string]*list.Element),
+		buckets:    list.New(),
+		clock:      clockOrReal(config.Clock),
+	}
+}
+
+// Get retrieves a value from the cache and increments its frequency
+func (c *LFUCache[V]) Get(ctx context.Context, key string) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	entry, ok := c.get(key, c.clock.Now())
+	if !ok {
+		return zero, ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+// Set stores a value in the cache with a TTL
+// A TTL of zero or less means the item does not expire
+// Overwriting an existing item counts as a use and increments its frequency
+func (c *LFUCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value, ttl, c.clock.Now())
+	return nil
+}
+
+// GetTTL returns the remaining time-to-live of a key without changing its frequency
+// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+func (c *LFUCache[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	elem, ok := c.items[key]
+	if !ok {
+		return 0, ErrCacheMiss
+	}
+	entry := elem.Value.(*lfuEntry[V])
+	if entry.expired(now) {
+		c.removeElement(elem)
+		return 0, ErrCacheMiss
+	}
+	if entry.expiresAt.IsZero() {
+		return 0, ErrNoExpiry
+	}
+	return entry.expiresAt.Sub(now), nil
+}
+
+// Delete removes a value from the cache
+func (c *LFUCache[V]) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return ErrCacheMiss
+	}
+	expired := elem.Value.(*lfuEntry[V]).expired(c.clock.Now())
+	c.removeElement(elem)
+	if expired {
+		return ErrCacheMiss
+	}
+	return nil
+}
+
+// BatchGet retrieves multiple values from the cache
+// Returns a map of key-value pairs for found keys
+// Missing keys are simply not included in the returned map
+func (c *LFUCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	results := make(map[string]V, len(keys))
+	for _, key := range keys {
+		if entry, ok := c.get(key, now); ok {
+			results[key] = entry.value
+		}
+	}
+	return results, nil
+}
+
+// BatchSet stores multiple values in the cache with a TTL
+// All items share the same TTL
+func (c *LFUCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for key, value := range items {
+		c.set(key, value, ttl, now)
+	}
+	return nil
+}
+
+// BatchDelete removes multiple values from the cache
+// Missing keys are ignored
+func (c *LFUCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.items[key]; ok {
+			c.removeElement(elem)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of items in the cache, including expired items not yet removed
+func (c *LFUCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.items)
+}
+
+// Stats returns the current size and the number of evictions since the cache was created
+func (c *LFUCache[V]) Stats() LFUStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return LFUStats{
+		Size:      len(c.items),
+		Evictions: c.evictions,
+	}
+}
+
+// Clear removes all items from the cache
+// The eviction count is kept
+func (c *LFUCache[V]) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*list.Element)
+	c.buckets.Init()
+	return nil
+}
+
+// expired reports whether the entry has expired at now
+func (e *lfuEntry[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// get returns the entry for key and increments its frequency
+// Expired entries are removed and reported as missing
+// Callers must hold c.mu
+func (c *LFUCache[V]) get(key string, now time.Time) (*lfuEntry[V], bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lfuEntry[V])
+	if entry.expired(now) {
+		c.removeElement(elem)
+		return nil, false
+	}
+	c.touch(elem)
+	return entry, true
+}
+
+// set stores a value and evicts the least frequently used item if the cache is full
+// Callers must hold c.mu
+func (c *LFUCache[V]) set(key string, value V, ttl time.Duration, now time.Time) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = now.Add(ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lfuEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.touch(elem)
+		return
+	}
+
+	if c.maxEntries > 0 && len(c.items) >= c.maxEntries {
+		c.evict()
+	}
+	bucket := c.buckets.Front()
+	if bucket == nil || bucket.Value.(*lfuBucket).freq != 1 {
+		bucket = c.buckets.PushFront(&lfuBucket{freq: 1, entries: list.New()})
+	}
+	entry := &lfuEntry[V]{key: key, value: value, bucket: bucket, expiresAt: expiresAt}
+	c.items[key] = bucket.Value.(*lfuBucket).entries.PushFront(entry)
+}
+
+// touch moves an element to the bucket of the next frequency, creating it if needed
+// Callers must hold c.mu
+func (c *LFUCache[V]) touch(elem *list.Element) {
+	entry := elem.Value.(*lfuEntry[V])
+	cur := entry.bucket
+	freq := cur.Value.(*lfuBucket).freq + 1
+	next := cur.Next()
+	if next == nil || next.Value.(*lfuBucket).freq != freq {
+		next = c.buckets.InsertAfter(&lfuBucket{freq: freq, entries: list.New()}, cur)
+	}
+	c.unlink(elem)
+	entry.bucket = next
+	c.items[entry.key] = next.Value.(*lfuBucket).entries.PushFront(entry)
+}
+
+// evict removes the least recently used item among the least frequently used ones
+// Callers must hold c.mu
+func (c *LFUCache[V]) evict() {
+	bucket := c.buckets.Front()
+	if bucket == nil {
+		return
+	}
+	c.removeElement(bucket.Value.(*lfuBucket).entries.Back())
+	c.evictions++
+}
+
+// removeElement removes an element from both its bucket and the index
+// Callers must hold c.mu
+func (c *LFUCache[V]) removeElement(elem *list.Element) {
+	c.unlink(elem)
+	delete(c.items, elem.Value.(*lfuEntry[V]).key)
+}
+
+// unlink removes an element from its bucket, dropping the bucket once it is empty
+// Callers must hold c.mu
+func (c *LFUCache[V]) unlink(elem *list.Element) {
+	bucket := elem.Value.(*lfuEntry[V]).bucket
+	entries := bucket.Value.(*lfuBucket).entries
+	entries.Remove(elem)
+	if entries.Len() == 0 {
+		c.buckets.Remove(bucket)
+	}
+}
@@ -0,0 +1,313 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRUCache implements the BatchCacher interface with an in-memory least-recently-used cache
+// Unlike RistrettoCache, writes are synchronous, so a Set is always visible to a following Get
+// Expired items are removed lazily when they are read
+type LRUCache[V any] struct {
+	mu         sync.Mutex
+	maxEntries int
+	lru        lruList[V]
+	clock      Clock
+}
+
+// LRUCacheConfig holds configuration for LRUCache
+type LRUCacheConfig struct {
+	// MaxEntries is the maximum number of items in cache.
+	// When the cache is full, the least recently used item is evicted.
+	MaxEntries int
+
+	// Clock is the time source for TTLs (default: the system clock).
+	Clock Clock
+}
+
+// DefaultLRUCacheConfig returns a default configuration
+func DefaultLRUCacheConfig() *LRUCacheConfig {
+	return &LRUCacheConfig{
+		MaxEntries: 10000,
+		Clock:      RealClock(),
+	}
+}
+
+// NewLRUCache creates a new LRUCache instance
+func NewLRUCache[V any](config *LRUCacheConfig) *LRUCache[V] {
+	if config == nil {
+		config = DefaultLRUCacheConfig()
+	}
+	return &LRUCache[V]{
+		maxEntries: config.MaxEntries,
+		lru:        newLRUList[V](),
+		clock:      clockOrReal(config.Clock),
+	}
+}
+
+// Get retrieves a value from the cache
+func (c *LRUCache[V]) Get(ctx context.Context, key string) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	entry, ok := c.lru.get(key, c.clock.Now())
+	if !ok {
+		return zero, ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+// Set stores a value in the cache with a TTL
+// A TTL of zero or less means the item does not expire
+func (c *LRUCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value, ttl, c.clock.Now())
+	return nil
+}
+
+// GetTTL returns the remaining time-to-live of a key
+// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+func (c *LRUCache[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	entry, ok := c.lru.get(key, now)
+	if !ok {
+		return 0, ErrCacheMiss
+	}
+	if entry.expiresAt.IsZero() {
+		return 0, ErrNoExpiry
+	}
+	return entry.expiresAt.Sub(now), nil
+}
+
+// SetNX stores a value with a TTL only if the key does not exist
+// Returns true if the value was stored and false if the key already existed
+func (c *LRUCache[V]) SetNX(ctx context.Context, key string, value V, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if _, ok := c.lru.get(key, now); ok {
+		return false, nil
+	}
+	c.set(key, value, ttl, now)
+	return true, nil
+}
+
+// Touch resets the TTL of a key without changing its value
+// Returns ErrCacheMiss if the key is not found
+func (c *LRUCache[V]) Touch(ctx context.Context, key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	entry, ok := c.lru.get(key, now)
+	if !ok {
+		return ErrCacheMiss
+	}
+	entry.expiresAt = time.Time{}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	return nil
+}
+
+// SetIfPresent stores a value with a TTL only if the key exists
+// Returns true if the value was stored and false if the key did not exist
+func (c *LRUCache[V]) SetIfPresent(ctx context.Context, key string, value V, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if _, ok := c.lru.get(key, now); !ok {
+		return false, nil
+	}
+	c.set(key, value, ttl, now)
+	return true, nil
+}
+
+// Delete removes a value from the cache
+func (c *LRUCache[V]) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.lru.get(key, c.clock.Now()); !ok {
+		return ErrCacheMiss
+	}
+	c.lru.remove(key)
+	return nil
+}
+
+// BatchGet retrieves multiple values from the cache
+// Returns a map of key-value pairs for found keys
+// Missing keys are simply not included in the returned map
+func (c *LRUCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	results := make(map[string]V, len(keys))
+	for _, key := range keys {
+		if entry, ok := c.lru.get(key, now); ok {
+			results[key] = entry.value
+		}
+	}
+	return results, nil
+}
+
+// BatchSet stores multiple values in the cache with a TTL
+// All items share the same TTL
+func (c *LRUCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for key, value := range items {
+		c.set(key, value, ttl, now)
+	}
+	return nil
+}
+
+// BatchDelete removes multiple values from the cache
+// Missing keys are ignored
+func (c *LRUCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		c.lru.remove(key)
+	}
+	return nil
+}
+
+// Len returns the number of items in the cache, including expired items not yet removed
+func (c *LRUCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.len()
+}
+
+// Clear removes all items from the cache
+func (c *LRUCache[V]) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.clear()
+	return nil
+}
+
+// set stores a value and evicts the least recently used item if the cache is full
+// Callers must hold c.mu
+func (c *LRUCache[V]) set(key string, value V, ttl time.Duration, now time.Time) {
+	c.lru.set(key, value, 0, ttl, now)
+	if c.maxEntries > 0 && c.lru.len() > c.maxEntries {
+		c.lru.removeOldest()
+	}
+}
+
+// lruList is the recency list and key index shared by LRUCache and ByteSizeCache
+// Each entry carries a size, and size is the total of all entries; LRUCache stores every entry with size 0,
+// while ByteSizeCache evicts based on the total. lruList is not safe for concurrent use
+type lruList[V any] struct {
+	ll    *list.List
+	items map[string]*list.Element
+	size  int64
+}
+
+// lruEntry is the value stored in each list element
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	size      int64
+	expiresAt time.Time // zero means no expiry
+}
+
+// newLRUList creates an empty lruList
+func newLRUList[V any]() lruList[V] {
+	return lruList[V]{
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get returns the entry for key and marks it as recently used
+// Expired entries are removed and reported as missing
+func (l *lruList[V]) get(key string, now time.Time) (*lruEntry[V], bool) {
+	elem, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry[V])
+	if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+		l.removeElement(elem)
+		return nil, false
+	}
+	l.ll.MoveToFront(elem)
+	return entry, true
+}
+
+// set stores a value of the given size as the most recently used entry
+// A TTL of zero or less means the entry does not expire. Nothing is evicted; callers enforce their bound
+func (l *lruList[V]) set(key string, value V, size int64, ttl time.Duration, now time.Time) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = now.Add(ttl)
+	}
+
+	if elem, ok := l.items[key]; ok {
+		entry := elem.Value.(*lruEntry[V])
+		l.size += size - entry.size
+		entry.value = value
+		entry.size = size
+		entry.expiresAt = expiresAt
+		l.ll.MoveToFront(elem)
+		return
+	}
+
+	l.items[key] = l.ll.PushFront(&lruEntry[V]{key: key, value: value, size: size, expiresAt: expiresAt})
+	l.size += size
+}
+
+// remove removes key if present and reports whether it was
+func (l *lruList[V]) remove(key string) bool {
+	elem, ok := l.items[key]
+	if ok {
+		l.removeElement(elem)
+	}
+	return ok
+}
+
+// removeOldest removes the least recently used entry
+func (l *lruList[V]) removeOldest() {
+	if elem := l.ll.Back(); elem != nil {
+		l.removeElement(elem)
+	}
+}
+
+// removeElement removes an element from both the list and the index
+func (l *lruList[V]) removeElement(elem *list.Element) {
+	entry := elem.Value.(*lruEntry[V])
+	l.ll.Remove(elem)
+	delete(l.items, entry.key)
+	l.size -= entry.size
+}
+
+// len returns the number of entries, including expired entries not yet removed
+func (l *lruList[V]) len() int {
+	return l.ll.Len()
+}
+
+// clear removes all entries
+func (l *lruList[V]) clear() {
+	l.ll.Init()
+	l.items = make(map[string]*list.Element)
+	l.size = 0
+}
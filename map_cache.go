@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MapCache implements the BatchCacher interface with a plain map guarded by a sync.RWMutex
+// It never evicts, writes are synchronous and TTLs are enforced lazily on read,
+// which makes it deterministic and convenient for tests and tiny datasets
+type MapCache[V any] struct {
+	mu    sync.RWMutex
+	items map[string]mapEntry[V]
+	clock Clock
+}
+
+// mapEntry is the value stored in the map
+type mapEntry[V any] struct {
+	value     V
+	expiresAt time.Time // zero means no expiry
+}
+
+// expired reports whether the entry has expired at now
+func (e mapEntry[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MapCacheConfig holds configuration for MapCache
+type MapCacheConfig struct {
+	// Clock is the time source for TTLs (default: the system clock).
+	Clock Clock
+}
+
+// DefaultMapCacheConfig returns a default configuration
+func DefaultMapCacheConfig() *MapCacheConfig {
+	return &MapCacheConfig{
+		Clock: RealClock(),
+	}
+}
+
+// NewMapCache creates a new MapCache instance
+func NewMapCache[V any]() *MapCache[V] {
+	return NewMapCacheWithConfig[V](nil)
+}
+
+// NewMapCacheWithConfig creates a new MapCache instance with the given configuration
+// If config is nil, DefaultMapCacheConfig is used
+func NewMapCacheWithConfig[V any](config *MapCacheConfig) *MapCache[V] {
+	if config == nil {
+		config = DefaultMapCacheConfig()
+	}
+	return &MapCache[V]{
+		items: make(map[string]mapEntry[V]),
+		clock: clockOrReal(config.Clock),
+	}
+}
+
+// Get retrieves a value from the cache
+// Returns ErrCacheMiss if the key is not found or has expired
+func (m *MapCache[V]) Get(ctx context.Context, key string) (V, error) {
+	var zero V
+	entry, ok := m.get(key, m.clock.Now())
+	if !ok {
+		return zero, ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+// Set stores a value in the cache with a TTL
+// A TTL of zero or less means no expiry
+func (m *MapCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items[key] = newMapEntry(value, ttl, m.clock.Now())
+	return nil
+}
+
+// GetTTL returns the remaining time-to-live of a key
+// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+func (m *MapCache[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	now := m.clock.Now()
+	entry, ok := m.get(key, now)
+	if !ok {
+		return 0, ErrCacheMiss
+	}
+	if entry.expiresAt.IsZero() {
+		return 0, ErrNoExpiry
+	}
+	return entry.expiresAt.Sub(now), nil
+}
+
+// Delete removes a value from the cache
+// Returns ErrCacheMiss if the key is not found or has expired
+func (m *MapCache[V]) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.items[key]
+	if !ok {
+		return ErrCacheMiss
+	}
+	delete(m.items, key)
+	if entry.expired(m.clock.Now()) {
+		return ErrCacheMiss
+	}
+	return nil
+}
+
+// BatchGet retrieves multiple values from the cache
+// Returns a map of key-value pairs for found keys
+// Missing and expired keys are simply not included in the returned map
+func (m *MapCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	now := m.clock.Now()
+	results := make(map[string]V, len(keys))
+	for _, key := range keys {
+		if entry, ok := m.get(key, now); ok {
+			results[key] = entry.value
+		}
+	}
+	return results, nil
+}
+
+// BatchSet stores multiple values in the cache with a TTL
+// All items share the same TTL
+func (m *MapCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	for key, value := range items {
+		m.items[key] = newMapEntry(value, ttl, now)
+	}
+	return nil
+}
+
+// BatchDelete removes multiple values from the cache
+// Missing keys are ignored
+func (m *MapCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.items, key)
+	}
+	return nil
+}
+
+// Len returns the number of unexpired items in the cache
+func (m *MapCache[V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := m.clock.Now()
+	n := 0
+	for _, entry := range m.items {
+		if !entry.expired(now) {
+			n++
+		}
+	}
+	return n
+}
+
+// Clear removes all items from the cache
+func (m *MapCache[V]) Clear(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items = make(map[string]mapEntry[V])
+	return nil
+}
+
+// get returns the entry for key if it exists and has not expired at now
+// Expired entries are removed
+func (m *MapCache[V]) get(key string, now time.Time) (mapEntry[V], bool) {
+	m.mu.RLock()
+	entry, ok := m.items[key]
+	m.mu.RUnlock()
+	if !ok {
+		return entry, false
+	}
+	if !entry.expired(now) {
+		return entry, true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// The key may have been rewritten since the read lock was released
+	if current, ok := m.items[key]; ok && current.expired(now) {
+		delete(m.items, key)
+	}
+	return mapEntry[V]{}, false
+}
+
+// newMapEntry creates an entry expiring ttl after now, or never if ttl is not positive
+func newMapEntry[V any](value V, ttl time.Duration, now time.Time) mapEntry[V] {
+	entry := mapEntry[V]{value: value}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	return entry
+}
@@ -0,0 +1,21 @@
+package cache
+
+import "context"
+
+// metadataKey is the context key for request metadata of type T
+// Each type gets its own key, so metadata of different types never collide
+type metadataKey[T any] struct{}
+
+// WithMetadata returns a copy of ctx carrying value as the request metadata of type T
+// Compute functions can read it with MetadataFrom instead of closing over request-scoped data
+// (e.g., a tenant ID), so a single compute function can be shared by all requests
+func WithMetadata[T any](ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, metadataKey[T]{}, value)
+}
+
+// MetadataFrom returns the request metadata of type T carried by ctx
+// Returns false if ctx carries no metadata of that type
+func MetadataFrom[T any](ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(metadataKey[T]{}).(T)
+	return value, ok
+}
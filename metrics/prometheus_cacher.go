@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	cache "github.com/naoto0822/exp-go-cache"
+)
+
+const (
+	operationGet    = "get"
+	operationSet    = "set"
+	operationDelete = "delete"
+
+	resultHit     = "hit"
+	resultMiss    = "miss"
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// PrometheusCacher wraps a Cacher and records Prometheus metrics for every operation
+// Metrics:
+//   - cache_operations_total{cache, operation, result}: result is hit/miss/error for get and success/error otherwise
+//   - cache_operation_duration_seconds{cache, operation}: operation latency
+type PrometheusCacher[V any] struct {
+	inner      cache.Cacher[V]
+	name       string
+	operations *prometheus.CounterVec
+	durations  *prometheus.HistogramVec
+}
+
+// PrometheusConfig holds configuration for PrometheusCacher
+type PrometheusConfig struct {
+	// Name is the value of the "cache" label, used to tell wrapped caches apart (e.g., "redis")
+	Name string
+
+	// Registerer is the registry the metrics are registered with.
+	// If nil, prometheus.DefaultRegisterer is used.
+	Registerer prometheus.Registerer
+
+	// Buckets are the histogram buckets for operation latency in seconds.
+	// If nil, prometheus.DefBuckets is used.
+	Buckets []float64
+}
+
+// DefaultPrometheusConfig returns a default configuration
+func DefaultPrometheusConfig() *PrometheusConfig {
+	return &PrometheusConfig{
+		Name:       "default",
+		Registerer: prometheus.DefaultRegisterer,
+		Buckets:    prometheus.DefBuckets,
+	}
+}
+
+// NewPrometheusCacher creates a new PrometheusCacher wrapping the given cache
+// Multiple caches can share a registry; the metrics are registered once and told apart by the "cache" label
+func NewPrometheusCacher[V any](inner cache.Cacher[V], config *PrometheusConfig) (*PrometheusCacher[V], error) {
+	if config == nil {
+		config = DefaultPrometheusConfig()
+	}
+	registerer := config.Registerer
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	buckets := config.Buckets
+	if buckets == nil {
+		buckets = prometheus.DefBuckets
+	}
+
+	operations := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_operations_total",
+		Help: "Total number of cache operations by result.",
+	}, []string{"cache", "operation", "result"})
+	if err := registerer.Register(operations); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			return nil, err
+		}
+		existing, ok := are.ExistingCollector.(*prometheus.CounterVec)
+		if !ok {
+			return nil, err
+		}
+		operations = existing
+	}
+
+	durations := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cache_operation_duration_seconds",
+		Help:    "Latency of cache operations in seconds.",
+		Buckets: buckets,
+	}, []string{"cache", "operation"})
+	if err := registerer.Register(durations); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			return nil, err
+		}
+		existing, ok := are.ExistingCollector.(*prometheus.HistogramVec)
+		if !ok {
+			return nil, err
+		}
+		durations = existing
+	}
+
+	return &PrometheusCacher[V]{
+		inner:      inner,
+		name:       config.Name,
+		operations: operations,
+		durations:  durations,
+	}, nil
+}
+
+// Get retrieves a value from the wrapped cache and records a hit, miss, or error
+func (p *PrometheusCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	start := time.Now()
+	value, err := p.inner.Get(ctx, key)
+	p.observe(operationGet, start)
+
+	switch {
+	case err == nil:
+		p.count(operationGet, resultHit)
+	case errors.Is(err, cache.ErrCacheMiss):
+		p.count(operationGet, resultMiss)
+	default:
+		p.count(operationGet, resultError)
+	}
+	return value, err
+}
+
+// Set stores a value in the wrapped cache and records a success or error
+func (p *PrometheusCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	start := time.Now()
+	err := p.inner.Set(ctx, key, value, ttl)
+	p.observe(operationSet, start)
+	p.countResult(operationSet, err)
+	return err
+}
+
+// Delete removes a value from the wrapped cache and records a success or error
+// A cache miss on delete is counted as a success
+func (p *PrometheusCacher[V]) Delete(ctx context.Context, key string) error {
+	start := time.Now()
+	err := p.inner.Delete(ctx, key)
+	p.observe(operationDelete, start)
+	if errors.Is(err, cache.ErrCacheMiss) {
+		p.countResult(operationDelete, nil)
+	} else {
+		p.countResult(operationDelete, err)
+	}
+	return err
+}
+
+// observe records the latency of an operation that started at start
+func (p *PrometheusCacher[V]) observe(operation string, start time.Time) {
+	p.durations.WithLabelValues(p.name, operation).Observe(time.Since(start).Seconds())
+}
+
+// count increments the operation counter for the given result
+func (p *PrometheusCacher[V]) count(operation, result string) {
+	p.operations.WithLabelValues(p.name, operation, result).Inc()
+}
+
+// countResult increments the operation counter with success or error depending on err
+func (p *PrometheusCacher[V]) countResult(operation string, err error) {
+	if err != nil {
+		p.count(operation, resultError)
+		return
+	}
+	p.count(operation, resultSuccess)
+}
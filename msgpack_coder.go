@@ -11,10 +11,42 @@ type MessagePackCoder[V any] struct {
 	handle *codec.MsgpackHandle
 }
 
+// MessagePackOption configures the codec.MsgpackHandle of a MessagePackCoder
+type MessagePackOption func(*codec.MsgpackHandle)
+
+// WithMessagePackRawToString decodes raw bytes into interface{} values as strings instead of []byte
+func WithMessagePackRawToString(rawToString bool) MessagePackOption {
+	return func(h *codec.MsgpackHandle) {
+		h.RawToString = rawToString
+	}
+}
+
+// WithMessagePackWriteExt encodes with the new MessagePack spec, including the str8 and bin types
+// and extension types such as the timestamp extension for time.Time
+// Leave it disabled for peers that only understand the old spec
+func WithMessagePackWriteExt(writeExt bool) MessagePackOption {
+	return func(h *codec.MsgpackHandle) {
+		h.WriteExt = writeExt
+	}
+}
+
+// WithMessagePackStructTags sets the struct tag keys read for field names and options, in order of precedence
+// (e.g., "msgpack", "json"); the default reads the "codec" and "json" tags
+func WithMessagePackStructTags(tags ...string) MessagePackOption {
+	return func(h *codec.MsgpackHandle) {
+		h.TypeInfos = codec.NewTypeInfos(tags)
+	}
+}
+
 // NewMessagePackCoder creates a new MessagePackCoder instance
-func NewMessagePackCoder[V any]() *MessagePackCoder[V] {
+// Without options, it uses a zero-value codec.MsgpackHandle
+func NewMessagePackCoder[V any](opts ...MessagePackOption) *MessagePackCoder[V] {
+	handle := &codec.MsgpackHandle{}
+	for _, opt := range opts {
+		opt(handle)
+	}
 	return &MessagePackCoder[V]{
-		handle: &codec.MsgpackHandle{},
+		handle: handle,
 	}
 }
 
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// NamespaceCacher wraps a Cacher and transparently prepends a prefix to every key
+// This avoids key collisions between services sharing the same backend (e.g., a Redis instance)
+// Returned maps are keyed by the caller's original, unprefixed keys
+type NamespaceCacher[V any] struct {
+	inner  Cacher[V]
+	prefix string
+}
+
+// NewNamespaceCacher creates a new NamespaceCacher wrapping the given cache
+// prefix is prepended as-is, so include a separator if needed (e.g., "svcA:")
+func NewNamespaceCacher[V any](inner Cacher[V], prefix string) *NamespaceCacher[V] {
+	return &NamespaceCacher[V]{
+		inner:  inner,
+		prefix: prefix,
+	}
+}
+
+// Get retrieves a value from the wrapped cache using the prefixed key
+func (n *NamespaceCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	return n.inner.Get(ctx, n.prefix+key)
+}
+
+// Set stores a value in the wrapped cache using the prefixed key
+func (n *NamespaceCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return n.inner.Set(ctx, n.prefix+key, value, ttl)
+}
+
+// Delete removes a value from the wrapped cache using the prefixed key
+func (n *NamespaceCacher[V]) Delete(ctx context.Context, key string) error {
+	return n.inner.Delete(ctx, n.prefix+key)
+}
+
+// BatchGet retrieves multiple values from the wrapped cache using prefixed keys
+// The returned map is keyed by the original keys
+func (n *NamespaceCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = n.prefix + key
+	}
+
+	found, err := batchGet(ctx, n.inner, prefixed)
+	results := make(map[string]V, len(found))
+	for i, key := range prefixed {
+		if value, ok := found[key]; ok {
+			results[keys[i]] = value
+		}
+	}
+	return results, err
+}
+
+// BatchSet stores multiple values in the wrapped cache using prefixed keys
+func (n *NamespaceCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	prefixed := make(map[string]V, len(items))
+	for key, value := range items {
+		prefixed[n.prefix+key] = value
+	}
+	return batchSet(ctx, n.inner, prefixed, ttl)
+}
+
+// BatchDelete removes multiple values from the wrapped cache using prefixed keys
+func (n *NamespaceCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = n.prefix + key
+	}
+	return batchDelete(ctx, n.inner, prefixed)
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache using the prefixed key
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (n *NamespaceCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	return forwardSetNotFound(ctx, n.inner, n.prefix+key, ttl)
+}
+
+// GetTTL returns the remaining time-to-live of the prefixed key in the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (n *NamespaceCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return forwardGetTTL(ctx, n.inner, n.prefix+key)
+}
+
+// Ping checks the wrapped cache's backend
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (n *NamespaceCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, n.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (n *NamespaceCacher[V]) Close() error {
+	return forwardClose(n.inner)
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// NoopCache implements the BatchCacher interface without storing anything
+// Every read is a miss and every write is discarded, which makes it useful for
+// disabling caching via dependency injection or measuring the uncached baseline
+// It can be used as any tier in NewTieredCache or NewBatchTieredCache
+type NoopCache[V any] struct{}
+
+// NewNoopCache creates a new NoopCache instance
+func NewNoopCache[V any]() *NoopCache[V] {
+	return &NoopCache[V]{}
+}
+
+// Get always returns ErrCacheMiss
+func (n *NoopCache[V]) Get(ctx context.Context, key string) (V, error) {
+	var zero V
+	return zero, ErrCacheMiss
+}
+
+// Set discards the value
+func (n *NoopCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return nil
+}
+
+// Delete does nothing and returns nil
+func (n *NoopCache[V]) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+// BatchGet always returns an empty map
+func (n *NoopCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	return make(map[string]V), nil
+}
+
+// BatchSet discards the values
+func (n *NoopCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	return nil
+}
+
+// BatchDelete does nothing and returns nil
+func (n *NoopCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	return nil
+}
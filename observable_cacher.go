@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Operation identifies a cache operation in an Event
+type Operation string
+
+const (
+	OperationGet         Operation = "get"
+	OperationSet         Operation = "set"
+	OperationDelete      Operation = "delete"
+	OperationBatchGet    Operation = "batch_get"
+	OperationBatchSet    Operation = "batch_set"
+	OperationBatchDelete Operation = "batch_delete"
+	OperationSetNotFound Operation = "set_not_found"
+	OperationGetTTL      Operation = "get_ttl"
+)
+
+// Event describes a completed cache operation
+type Event struct {
+	// Operation is the operation that was performed
+	Operation Operation
+
+	// Key is the key of a single-key operation (empty for batch operations)
+	Key string
+
+	// BatchSize is the number of keys or items of a batch operation (1 for single-key operations)
+	BatchSize int
+
+	// Hit reports whether Get or GetTTL found the key, or whether BatchGet found at least one key
+	Hit bool
+
+	// Hits is the number of keys found by Get or BatchGet
+	Hits int
+
+	// Duration is how long the operation took
+	Duration time.Duration
+
+	// Err is the error returned by the operation, if any (ErrCacheMiss for a Get miss)
+	Err error
+}
+
+// ObservableCacher wraps a Cacher and reports every operation to an OnEvent hook
+// This allows plugging in structured logging (e.g., slog or zap) or custom metrics
+// without coupling the cache to a specific library
+type ObservableCacher[V any] struct {
+	inner   Cacher[V]
+	onEvent func(Event)
+}
+
+// NewObservableCacher creates a new ObservableCacher wrapping the given cache
+// onEvent is called synchronously after each operation, so it should be fast
+func NewObservableCacher[V any](inner Cacher[V], onEvent func(Event)) *ObservableCacher[V] {
+	return &ObservableCacher[V]{
+		inner:   inner,
+		onEvent: onEvent,
+	}
+}
+
+// Get retrieves a value from the wrapped cache and reports the result
+func (o *ObservableCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	start := time.Now()
+	value, err := o.inner.Get(ctx, key)
+	hits := 0
+	if err == nil {
+		hits = 1
+	}
+	o.emit(Event{Operation: OperationGet, Key: key, BatchSize: 1, Hit: err == nil, Hits: hits, Duration: time.Since(start), Err: err})
+	return value, err
+}
+
+// Set stores a value in the wrapped cache and reports the result
+func (o *ObservableCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	start := time.Now()
+	err := o.inner.Set(ctx, key, value, ttl)
+	o.emit(Event{Operation: OperationSet, Key: key, BatchSize: 1, Duration: time.Since(start), Err: err})
+	return err
+}
+
+// Delete removes a value from the wrapped cache and reports the result
+func (o *ObservableCacher[V]) Delete(ctx context.Context, key string) error {
+	start := time.Now()
+	err := o.inner.Delete(ctx, key)
+	o.emit(Event{Operation: OperationDelete, Key: key, BatchSize: 1, Hit: !errors.Is(err, ErrCacheMiss), Duration: time.Since(start), Err: err})
+	return err
+}
+
+// BatchGet retrieves multiple values from the wrapped cache and reports the result
+func (o *ObservableCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	start := time.Now()
+	results, err := batchGet(ctx, o.inner, keys)
+	o.emit(Event{Operation: OperationBatchGet, BatchSize: len(keys), Hit: len(results) > 0, Hits: len(results), Duration: time.Since(start), Err: err})
+	return results, err
+}
+
+// BatchSet stores multiple values in the wrapped cache and reports the result
+func (o *ObservableCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	start := time.Now()
+	err := batchSet(ctx, o.inner, items, ttl)
+	o.emit(Event{Operation: OperationBatchSet, BatchSize: len(items), Duration: time.Since(start), Err: err})
+	return err
+}
+
+// BatchDelete removes multiple values from the wrapped cache and reports the result
+func (o *ObservableCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	start := time.Now()
+	err := batchDelete(ctx, o.inner, keys)
+	o.emit(Event{Operation: OperationBatchDelete, BatchSize: len(keys), Duration: time.Since(start), Err: err})
+	return err
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache and reports the result
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (o *ObservableCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	start := time.Now()
+	err := forwardSetNotFound(ctx, o.inner, key, ttl)
+	o.emit(Event{Operation: OperationSetNotFound, Key: key, BatchSize: 1, Duration: time.Since(start), Err: err})
+	return err
+}
+
+// GetTTL returns the remaining time-to-live of a key in the wrapped cache and reports the result
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (o *ObservableCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	start := time.Now()
+	ttl, err := forwardGetTTL(ctx, o.inner, key)
+	o.emit(Event{Operation: OperationGetTTL, Key: key, BatchSize: 1, Hit: err == nil || errors.Is(err, ErrNoExpiry), Duration: time.Since(start), Err: err})
+	return ttl, err
+}
+
+// Ping checks the wrapped cache's backend
+// Health checks are not reported as events
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (o *ObservableCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, o.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (o *ObservableCacher[V]) Close() error {
+	return forwardClose(o.inner)
+}
+
+// emit reports an event to the hook if one is configured
+func (o *ObservableCacher[V]) emit(event Event) {
+	if o.onEvent != nil {
+		o.onEvent(event)
+	}
+}
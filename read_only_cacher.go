@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// ReadOnlyCacher wraps a Cacher and passes reads through while blocking every write
+// It guarantees that code paths such as read replicas never write to a shared cache.
+// GetTTL and Ping are reads and pass through, and Close closes the wrapped cache.
+// Blocked writes return ErrReadOnly, or are silently ignored if Frozen is set
+type ReadOnlyCacher[V any] struct {
+	inner  Cacher[V]
+	frozen bool
+}
+
+// ReadOnlyConfig holds configuration for ReadOnlyCacher
+type ReadOnlyConfig struct {
+	// Frozen makes blocked writes silent no-ops that return nil.
+	// If false, they return ErrReadOnly so accidental writes surface as errors.
+	Frozen bool
+}
+
+// DefaultReadOnlyConfig returns a default configuration
+func DefaultReadOnlyConfig() *ReadOnlyConfig {
+	return &ReadOnlyConfig{
+		Frozen: false,
+	}
+}
+
+// NewReadOnlyCacher creates a new ReadOnlyCacher wrapping the given cache
+func NewReadOnlyCacher[V any](inner Cacher[V], config *ReadOnlyConfig) *ReadOnlyCacher[V] {
+	if config == nil {
+		config = DefaultReadOnlyConfig()
+	}
+	return &ReadOnlyCacher[V]{
+		inner:  inner,
+		frozen: config.Frozen,
+	}
+}
+
+// Get retrieves a value from the wrapped cache
+func (r *ReadOnlyCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	return r.inner.Get(ctx, key)
+}
+
+// Set is blocked and returns ErrReadOnly, or nil if Frozen is set
+func (r *ReadOnlyCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return r.blocked()
+}
+
+// SetNotFound is blocked and returns ErrReadOnly, or nil if Frozen is set
+func (r *ReadOnlyCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	return r.blocked()
+}
+
+// Delete is blocked and returns ErrReadOnly, or nil if Frozen is set
+func (r *ReadOnlyCacher[V]) Delete(ctx context.Context, key string) error {
+	return r.blocked()
+}
+
+// BatchGet retrieves multiple values from the wrapped cache
+func (r *ReadOnlyCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	return batchGet(ctx, r.inner, keys)
+}
+
+// BatchSet is blocked and returns ErrReadOnly, or nil if Frozen is set
+func (r *ReadOnlyCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	return r.blocked()
+}
+
+// BatchDelete is blocked and returns ErrReadOnly, or nil if Frozen is set
+func (r *ReadOnlyCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	return r.blocked()
+}
+
+// GetTTL returns the remaining time-to-live of a key in the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (r *ReadOnlyCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return forwardGetTTL(ctx, r.inner, key)
+}
+
+// Ping checks the wrapped cache's backend
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (r *ReadOnlyCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, r.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (r *ReadOnlyCacher[V]) Close() error {
+	return forwardClose(r.inner)
+}
+
+// blocked returns the result of a blocked write
+func (r *ReadOnlyCacher[V]) blocked() error {
+	if r.frozen {
+		return nil
+	}
+	return ErrReadOnly
+}
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedisCache creates a RedisCache backed by an in-memory miniredis server that is closed when the test ends
+// The server is returned so tests can inspect keys and fast-forward time
+func newTestRedisCache[V any](t testing.TB, config *RedisCacheConfig, coder Coder[V]) (*RedisCache[V], *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedisCacheWithClientAndConfig(client, config, coder), server
+}
+
+func TestRedisCacheNonPositiveTTLMeansNoExpiry(t *testing.T) {
+	ctx := context.Background()
+	c, server := newTestRedisCache[string](t, nil, nil)
+
+	for _, ttl := range []time.Duration{0, -1, -time.Hour} {
+		// An expiry left by a previous write must not survive an overwrite without expiry
+		if err := c.Set(ctx, "key", "old", time.Minute); err != nil {
+			t.Fatalf("Set(ttl=1m): %v", err)
+		}
+		if err := c.Set(ctx, "key", "value", ttl); err != nil {
+			t.Fatalf("Set(ttl=%s): %v", ttl, err)
+		}
+		if err := c.BatchSet(ctx, map[string]string{"a": "1", "b": "2"}, ttl); err != nil {
+			t.Fatalf("BatchSet(ttl=%s): %v", ttl, err)
+		}
+		if err := c.SetNotFound(ctx, "missing", ttl); err != nil {
+			t.Fatalf("SetNotFound(ttl=%s): %v", ttl, err)
+		}
+
+		server.FastForward(24 * time.Hour)
+
+		for _, key := range []string{"key", "a", "b", "missing"} {
+			if _, err := c.GetTTL(ctx, key); !errors.Is(err, ErrNoExpiry) {
+				t.Errorf("GetTTL(%q) after ttl=%s = %v, want ErrNoExpiry", key, ttl, err)
+			}
+		}
+		if got, err := c.Get(ctx, "key"); err != nil || got != "value" {
+			t.Errorf("Get after ttl=%s = %q, %v; want %q, nil", ttl, got, err, "value")
+		}
+	}
+}
+
+// BenchmarkRedisCacheBatchSet writes 1000 keys with BatchSet: without expiry as MSET commands,
+// and with a TTL as pipelined SET commands, each split into round trips of PipelineBatchSize keys
+// The server is in-process, so the results exclude network latency, which adds to every round trip
+func BenchmarkRedisCacheBatchSet(b *testing.B) {
+	const numKeys = 1000
+	items := make(map[string]benchRecord, numKeys)
+	for i := range numKeys {
+		items[fmt.Sprintf("key-%d", i)] = newBenchRecord()
+	}
+
+	for _, pipelineSize := range []int{0, 500, 100} {
+		for _, mode := range []struct {
+			name string
+			ttl  time.Duration
+		}{
+			{"MSET", 0},
+			{"PipelinedSET", time.Hour},
+		} {
+			b.Run(fmt.Sprintf("%s/PipelineBatchSize=%d", mode.name, pipelineSize), func(b *testing.B) {
+				config := DefaultRedisCacheConfig()
+				config.PipelineBatchSize = pipelineSize
+				c, _ := newTestRedisCache(b, config, NewMessagePackCoder[benchRecord]())
+				ctx := context.Background()
+
+				b.ReportAllocs()
+				for b.Loop() {
+					if err := c.BatchSet(ctx, items, mode.ttl); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestRedisCacheCorruptedPayload(t *testing.T) {
+	ctx := context.Background()
+	garbage := "\xff\x00not json"
+
+	t.Run("DecodeErrorByDefault", func(t *testing.T) {
+		c, server := newTestRedisCache[benchRecord](t, nil, nil)
+		if err := server.Set("key", garbage); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Get(ctx, "key"); err == nil || errors.Is(err, ErrCacheMiss) {
+			t.Errorf("Get = %v, want the decode error", err)
+		}
+		if !server.Exists("key") {
+			t.Error("undecodable key was deleted without DeleteOnDecodeError")
+		}
+	})
+
+	t.Run("DeleteOnDecodeError", func(t *testing.T) {
+		config := DefaultRedisCacheConfig()
+		config.DeleteOnDecodeError = true
+		c, server := newTestRedisCache[benchRecord](t, config, nil)
+
+		if err := server.Set("key", garbage); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Get(ctx, "key"); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("Get = %v, want ErrCacheMiss", err)
+		}
+		if server.Exists("key") {
+			t.Error("undecodable key was not deleted by Get")
+		}
+
+		if err := server.Set("key", garbage); err != nil {
+			t.Fatal(err)
+		}
+		result, err := c.BatchGetResult(ctx, []string{"key"})
+		if err != nil {
+			t.Fatalf("BatchGetResult: %v", err)
+		}
+		if len(result.Missed) != 1 || result.Missed[0] != "key" || len(result.Errors) != 0 {
+			t.Errorf("BatchGetResult = %+v, want key missed without errors", result)
+		}
+		if server.Exists("key") {
+			t.Error("undecodable key was not deleted by BatchGetResult")
+		}
+	})
+
+	t.Run("KeepsValueWrittenSinceRead", func(t *testing.T) {
+		config := DefaultRedisCacheConfig()
+		config.DeleteOnDecodeError = true
+		c, _ := newTestRedisCache[benchRecord](t, config, nil)
+
+		// A valid value replaced the undecodable one between the read and the delete
+		want := newBenchRecord()
+		if err := c.Set(ctx, "key", want, 0); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		c.deletePoisoned(ctx, "key", garbage, errors.New("decode failed"))
+
+		got, err := c.Get(ctx, "key")
+		if err != nil {
+			t.Fatalf("Get after deletePoisoned: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Get = %+v, want %+v", got, want)
+		}
+	})
+}
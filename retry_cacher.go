@@ -0,0 +1,210 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+// RetryCacher wraps a Cacher and retries operations that fail with transient errors
+// Retries use exponential backoff and stop early when the context is cancelled or its deadline
+// would pass before the next attempt. ErrCacheMiss, ErrNotFound, ErrNoExpiry, context errors, and errors rejecting
+// the call itself (e.g., ErrValueTooLarge, ErrReadOnly, ErrCircuitOpen) are never retried
+type RetryCacher[V any] struct {
+	inner          Cacher[V]
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	multiplier     float64
+	isRetryable    func(err error) bool
+}
+
+// RetryConfig holds configuration for RetryCacher
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts including the first one
+	MaxAttempts int
+
+	// InitialBackoff is the wait time before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait time between retries
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor the backoff grows by after each retry
+	Multiplier float64
+
+	// IsRetryable reports whether an error is transient and the operation should be retried.
+	// If nil, only transient errors are retried: network errors (net.Error, io.EOF, io.ErrUnexpectedEOF)
+	// and errors matching ErrCacheUnavailable or ErrCacheBackend.
+	// It is not called for the errors that are never retried.
+	IsRetryable func(err error) bool
+}
+
+// DefaultRetryConfig returns a default configuration
+func DefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		IsRetryable:    nil,
+	}
+}
+
+// NewRetryCacher creates a new RetryCacher wrapping the given cache
+func NewRetryCacher[V any](inner Cacher[V], config *RetryConfig) *RetryCacher[V] {
+	if config == nil {
+		config = DefaultRetryConfig()
+	}
+	maxAttempts := config.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	multiplier := config.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	return &RetryCacher[V]{
+		inner:          inner,
+		maxAttempts:    maxAttempts,
+		initialBackoff: config.InitialBackoff,
+		maxBackoff:     config.MaxBackoff,
+		multiplier:     multiplier,
+		isRetryable:    config.IsRetryable,
+	}
+}
+
+// Get retrieves a value from the wrapped cache, retrying on transient errors
+func (r *RetryCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	var value V
+	err := r.retry(ctx, func() error {
+		var err error
+		value, err = r.inner.Get(ctx, key)
+		return err
+	})
+	return value, err
+}
+
+// Set stores a value in the wrapped cache, retrying on transient errors
+func (r *RetryCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return r.retry(ctx, func() error {
+		return r.inner.Set(ctx, key, value, ttl)
+	})
+}
+
+// Delete removes a value from the wrapped cache, retrying on transient errors
+func (r *RetryCacher[V]) Delete(ctx context.Context, key string) error {
+	return r.retry(ctx, func() error {
+		return r.inner.Delete(ctx, key)
+	})
+}
+
+// BatchGet retrieves multiple values from the wrapped cache, retrying on transient errors
+func (r *RetryCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	var results map[string]V
+	err := r.retry(ctx, func() error {
+		var err error
+		results, err = batchGet(ctx, r.inner, keys)
+		return err
+	})
+	return results, err
+}
+
+// BatchSet stores multiple values in the wrapped cache, retrying on transient errors
+func (r *RetryCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	return r.retry(ctx, func() error {
+		return batchSet(ctx, r.inner, items, ttl)
+	})
+}
+
+// BatchDelete removes multiple values from the wrapped cache, retrying on transient errors
+func (r *RetryCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	return r.retry(ctx, func() error {
+		return batchDelete(ctx, r.inner, keys)
+	})
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache, retrying on transient errors
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (r *RetryCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	return r.retry(ctx, func() error {
+		return forwardSetNotFound(ctx, r.inner, key, ttl)
+	})
+}
+
+// GetTTL returns the remaining time-to-live of a key in the wrapped cache, retrying on transient errors
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (r *RetryCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	var ttl time.Duration
+	err := r.retry(ctx, func() error {
+		var err error
+		ttl, err = forwardGetTTL(ctx, r.inner, key)
+		return err
+	})
+	return ttl, err
+}
+
+// Ping checks the wrapped cache's backend
+// It is not retried, so a health check reports the backend's current state
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (r *RetryCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, r.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (r *RetryCacher[V]) Close() error {
+	return forwardClose(r.inner)
+}
+
+// retry executes op until it succeeds, fails with a non-retryable error, or runs out of attempts
+// Returns the error of the last attempt
+func (r *RetryCacher[V]) retry(ctx context.Context, op func() error) error {
+	backoff := r.initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= r.maxAttempts || !r.retryable(err) {
+			return err
+		}
+
+		// Give up if the context deadline would pass before the next attempt
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		backoff = time.Duration(float64(backoff) * r.multiplier)
+		if r.maxBackoff > 0 && backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+	}
+}
+
+// retryable reports whether err should be retried
+func (r *RetryCacher[V]) retryable(err error) bool {
+	if errors.Is(err, ErrCacheMiss) || errors.Is(err, ErrNotFound) || errors.Is(err, ErrNoExpiry) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || isRejection(err) {
+		return false
+	}
+	if r.isRetryable == nil {
+		return isTransient(err)
+	}
+	return r.isRetryable(err)
+}
+
+// isTransient reports whether err is likely to go away on its own, such as a dropped connection
+func isTransient(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, ErrCacheUnavailable) || errors.Is(err, ErrCacheBackend)
+}
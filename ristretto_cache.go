@@ -9,10 +9,22 @@ import (
 
 // RistrettoCache wraps ristretto cache to implement the LocalCacher interface with generic type support
 type RistrettoCache[V any] struct {
-	cache *ristretto.Cache
+	cache    *ristretto.Cache
+	costFunc func(value V) int64
 }
 
-type RistrettoCacheConfig struct {
+// ristrettoItem is the value stored in ristretto
+// It keeps the original key because ristretto only hands hashed keys to eviction callbacks
+type ristrettoItem[V any] struct {
+	key   string
+	value V
+}
+
+// ristrettoTombstone is stored in place of a value to mark a negative cache entry
+type ristrettoTombstone struct{}
+
+// RistrettoCacheConfig holds configuration for RistrettoCache
+type RistrettoCacheConfig[V any] struct {
 	// NumCounters determines the number of keys tracked for admission & eviction.
 	// A good starting point is 10x the number of items you expect to keep in cache.
 	NumCounters int64
@@ -24,60 +36,168 @@ type RistrettoCacheConfig struct {
 	// BufferItems is the size of the Get/Set buffers.
 	// A larger buffer improves throughput but uses more memory.
 	BufferItems int64
+
+	// CostFunc returns the cost of a value, e.g., its serialized size in bytes (optional).
+	// If nil, every item costs 1 and MaxCost limits the number of items.
+	CostFunc func(value V) int64
+
+	// OnEvict is called when an item is evicted due to capacity or expiry (optional).
+	// It is not called for explicit Delete calls.
+	OnEvict func(key string, value V)
+
+	// OnReject is called when the admission policy rejects an item on Set (optional)
+	OnReject func(key string, value V)
 }
 
-func DefaultRistrettoCacheConfig() *RistrettoCacheConfig {
-	return &RistrettoCacheConfig{
+// DefaultRistrettoCacheConfig returns a default configuration
+func DefaultRistrettoCacheConfig[V any]() *RistrettoCacheConfig[V] {
+	return &RistrettoCacheConfig[V]{
 		NumCounters: 1e7,     // 10 million counters
 		MaxCost:     1 << 30, // 1GB max cost
 		BufferItems: 64,
+		CostFunc:    nil,
+		OnEvict:     nil,
+		OnReject:    nil,
 	}
 }
 
 // NewRistrettoCache creates a new RistrettoCache instance
-func NewRistrettoCache[V any](config *RistrettoCacheConfig) (*RistrettoCache[V], error) {
+func NewRistrettoCache[V any](config *RistrettoCacheConfig[V]) (*RistrettoCache[V], error) {
 	if config == nil {
-		config = DefaultRistrettoCacheConfig()
+		config = DefaultRistrettoCacheConfig[V]()
 	}
 	cache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: config.NumCounters,
 		MaxCost:     config.MaxCost,
 		BufferItems: config.BufferItems,
+		OnEvict:     ristrettoCallback(config.OnEvict),
+		OnReject:    ristrettoCallback(config.OnReject),
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &RistrettoCache[V]{
-		cache: cache,
+		cache:    cache,
+		costFunc: config.CostFunc,
 	}, nil
 }
 
 // Get retrieves a value from the cache
+// Returns the context error if ctx is already done
 func (r *RistrettoCache[V]) Get(ctx context.Context, key string) (V, error) {
 	var zero V
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
 	value, found := r.cache.Get(key)
 	if !found {
 		return zero, ErrCacheMiss
 	}
+	if _, ok := value.(ristrettoTombstone); ok {
+		return zero, ErrNotFound
+	}
 	// Type assertion with safety check
-	if v, ok := value.(V); ok {
-		return v, nil
+	if item, ok := value.(ristrettoItem[V]); ok {
+		return item.value, nil
 	}
 	return zero, ErrCacheMiss
 }
 
 // Set stores a value in the cache with a TTL
+// Returns ErrSetDropped if ristretto drops the write; otherwise waits until the write is applied
+// so a following Get sees it. If ctx is done while waiting, the context error is returned,
+// but the write may still be applied
 func (r *RistrettoCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cost := r.cost(value)
+	if !r.set(key, ristrettoItem[V]{key: key, value: value}, cost, ttl) {
+		return ErrSetDropped
+	}
+	return r.wait(ctx)
+}
+
+// SetNX is not supported because ristretto has no atomic check-and-set
+// Always returns ErrUnsupported
+func (r *RistrettoCache[V]) SetNX(ctx context.Context, key string, value V, ttl time.Duration) (bool, error) {
+	return false, ErrUnsupported
+}
+
+// Touch resets the TTL of a key
+// ristretto cannot adjust a TTL in place, so the stored value (or negative cache entry) is set again with the new TTL.
+// This is not atomic with concurrent writes to the same key.
+// Returns ErrCacheMiss if the key is not found and ErrSetDropped if ristretto drops the write
+func (r *RistrettoCache[V]) Touch(ctx context.Context, key string, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	value, found := r.cache.Get(key)
+	if !found {
+		return ErrCacheMiss
+	}
 	cost := int64(1)
-	if !r.cache.SetWithTTL(key, value, cost, ttl) {
-		return nil
+	if item, ok := value.(ristrettoItem[V]); ok {
+		cost = r.cost(item.value)
 	}
-	r.cache.Wait()
-	return nil
+	if !r.set(key, value, cost, ttl) {
+		return ErrSetDropped
+	}
+	return r.wait(ctx)
+}
+
+// SetIfPresent is not supported because ristretto has no atomic check-and-set
+// Always returns ErrUnsupported
+func (r *RistrettoCache[V]) SetIfPresent(ctx context.Context, key string, value V, ttl time.Duration) (bool, error) {
+	return false, ErrUnsupported
+}
+
+// Increment is not supported because ristretto has no atomic read-modify-write
+// Always returns ErrUnsupported
+func (r *RistrettoCache[V]) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return 0, ErrUnsupported
+}
+
+// Decrement is not supported because ristretto has no atomic read-modify-write
+// Always returns ErrUnsupported
+func (r *RistrettoCache[V]) Decrement(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return 0, ErrUnsupported
+}
+
+// SetNotFound stores a negative cache entry with a TTL
+// Returns ErrSetDropped if ristretto drops the write
+func (r *RistrettoCache[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	cost := int64(1)
+	if !r.set(key, ristrettoTombstone{}, cost, ttl) {
+		return ErrSetDropped
+	}
+	return r.wait(ctx)
+}
+
+// GetTTL returns the remaining time-to-live of a key
+// Returns ErrCacheMiss if the key is not found and ErrNoExpiry if the key has no expiry
+func (r *RistrettoCache[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	ttl, found := r.cache.GetTTL(key)
+	if !found {
+		return 0, ErrCacheMiss
+	}
+	if ttl == 0 {
+		return 0, ErrNoExpiry
+	}
+	return ttl, nil
 }
 
 // Delete removes a value from the cache
 func (r *RistrettoCache[V]) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	_, found := r.cache.Get(key)
 	if !found {
 		return ErrCacheMiss
@@ -88,8 +208,11 @@ func (r *RistrettoCache[V]) Delete(ctx context.Context, key string) error {
 
 // BatchGet retrieves multiple values from the cache
 // Returns a map of key-value pairs for found keys
-// Missing keys are simply not included in the returned map
+// Missing keys and negative cache entries are simply not included in the returned map
 func (r *RistrettoCache[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	results := make(map[string]V, len(keys))
 	for _, key := range keys {
 		value, found := r.cache.Get(key)
@@ -97,8 +220,8 @@ func (r *RistrettoCache[V]) BatchGet(ctx context.Context, keys []string) (map[st
 			continue
 		}
 		// Type assertion with safety check
-		if v, ok := value.(V); ok {
-			results[key] = v
+		if item, ok := value.(ristrettoItem[V]); ok {
+			results[key] = item.value
 		}
 	}
 	return results, nil
@@ -106,24 +229,116 @@ func (r *RistrettoCache[V]) BatchGet(ctx context.Context, keys []string) (map[st
 
 // BatchSet stores multiple values in the cache with a TTL
 // All items share the same TTL
+// Returns ErrSetDropped if ristretto drops any of the writes; the accepted writes are still applied
 func (r *RistrettoCache[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
-	cost := int64(1)
+	return r.batchSet(ctx, items, func(string) time.Duration { return ttl })
+}
+
+// BatchSetWithTTLs stores multiple values in the cache, each with its own TTL
+// Keys missing from ttls are stored without expiry
+// Returns ErrSetDropped if ristretto drops any of the writes; the accepted writes are still applied
+func (r *RistrettoCache[V]) BatchSetWithTTLs(ctx context.Context, items map[string]V, ttls map[string]time.Duration) error {
+	return r.batchSet(ctx, items, func(key string) time.Duration { return ttls[key] })
+}
+
+// batchSet stores multiple values with the TTL returned by ttlOf and waits for the writes to be applied
+func (r *RistrettoCache[V]) batchSet(ctx context.Context, items map[string]V, ttlOf func(key string) time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	dropped := false
 	for key, value := range items {
-		r.cache.SetWithTTL(key, value, cost, ttl)
+		if !r.set(key, ristrettoItem[V]{key: key, value: value}, r.cost(value), ttlOf(key)) {
+			dropped = true
+		}
+	}
+	if err := r.wait(ctx); err != nil {
+		return err
+	}
+	if dropped {
+		return ErrSetDropped
+	}
+	return nil
+}
+
+// BatchDelete removes multiple values from the cache
+// Missing keys are ignored
+func (r *RistrettoCache[V]) BatchDelete(ctx context.Context, keys []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		r.cache.Del(key)
 	}
-	r.cache.Wait()
 	return nil
 }
 
+// set stores an item with a TTL, or without expiry if ttl is zero or less
+// ristretto's SetWithTTL treats a negative TTL as a no-op, so it is only used for positive TTLs
+// Returns false if ristretto drops the write
+func (r *RistrettoCache[V]) set(key string, value any, cost int64, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return r.cache.Set(key, value, cost)
+	}
+	return r.cache.SetWithTTL(key, value, cost, ttl)
+}
+
+// wait blocks until buffered writes are applied or ctx is done
+// Returns the context error if ctx is done first; the writes are still applied in the background
+func (r *RistrettoCache[V]) wait(ctx context.Context) error {
+	if ctx.Done() == nil {
+		r.cache.Wait()
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		r.cache.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cost returns the cost of a value using the configured CostFunc, or 1 if none is configured
+func (r *RistrettoCache[V]) cost(value V) int64 {
+	if r.costFunc == nil {
+		return 1
+	}
+	return r.costFunc(value)
+}
+
+// ristrettoCallback adapts a typed callback to ristretto's item callback
+// Items that are not ristrettoItem[V] (e.g., negative cache entries) are ignored
+func ristrettoCallback[V any](fn func(key string, value V)) func(item *ristretto.Item) {
+	if fn == nil {
+		return nil
+	}
+	return func(item *ristretto.Item) {
+		if ri, ok := item.Value.(ristrettoItem[V]); ok {
+			fn(ri.key, ri.value)
+		}
+	}
+}
+
 // Close closes the cache and releases resources
 func (r *RistrettoCache[V]) Close() error {
 	r.cache.Close()
 	return nil
 }
 
+// Ping always succeeds since the cache is in-memory
+func (r *RistrettoCache[V]) Ping(ctx context.Context) error {
+	return nil
+}
+
 // Clear removes all items from the cache
-func (r *RistrettoCache[V]) Clear() {
+func (r *RistrettoCache[V]) Clear(ctx context.Context) error {
 	r.cache.Clear()
+	return nil
 }
 
 // Metrics returns cache metrics from ristretto
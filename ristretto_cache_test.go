@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestRistrettoCache creates a small RistrettoCache that is closed when the test ends
+func newTestRistrettoCache[V any](t *testing.T) *RistrettoCache[V] {
+	t.Helper()
+	c, err := NewRistrettoCache(&RistrettoCacheConfig[V]{
+		NumCounters: 1000,
+		MaxCost:     1 << 20, // ristretto adds its internal per-item overhead to every cost
+		BufferItems: 64,
+	})
+	if err != nil {
+		t.Fatalf("NewRistrettoCache: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestRistrettoCacheSetIsVisibleToFollowingGet(t *testing.T) {
+	ctx := context.Background()
+	c := newTestRistrettoCache[string](t)
+
+	for _, ttl := range []time.Duration{0, time.Hour} {
+		if err := c.Set(ctx, "key", "value", ttl); err != nil {
+			t.Fatalf("Set(ttl=%s): %v", ttl, err)
+		}
+		got, err := c.Get(ctx, "key")
+		if err != nil || got != "value" {
+			t.Errorf("Get after Set(ttl=%s) = %q, %v; want %q, nil", ttl, got, err, "value")
+		}
+	}
+}
+
+func TestRistrettoCacheSetReportsDroppedWrite(t *testing.T) {
+	ctx := context.Background()
+	c := newTestRistrettoCache[string](t)
+
+	// ristretto rejects every write once closed, the same way it reports a write dropped from a full buffer
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	for _, ttl := range []time.Duration{0, time.Hour} {
+		if err := c.Set(ctx, "key", "value", ttl); !errors.Is(err, ErrSetDropped) {
+			t.Errorf("Set(ttl=%s) = %v, want ErrSetDropped", ttl, err)
+		}
+	}
+	if err := c.BatchSet(ctx, map[string]string{"a": "1", "b": "2"}, time.Hour); !errors.Is(err, ErrSetDropped) {
+		t.Errorf("BatchSet = %v, want ErrSetDropped", err)
+	}
+}
+
+func TestTieredCacheToleratesDroppedWrites(t *testing.T) {
+	ctx := context.Background()
+	l1 := newTestRistrettoCache[string](t)
+	l2 := NewLRUCache[string](nil)
+	if err := l1.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	tc := NewTieredCache[string](l1, l2)
+	got, err := tc.Get(ctx, "key", time.Hour, func(ctx context.Context, key string) (string, error) {
+		return "computed", nil
+	})
+	if err != nil || got != "computed" {
+		t.Fatalf("Get = %q, %v; want %q, nil", got, err, "computed")
+	}
+	if got, err := l2.Get(ctx, "key"); err != nil || got != "computed" {
+		t.Errorf("L2 Get = %q, %v; want %q, nil", got, err, "computed")
+	}
+}
+
+func TestRistrettoCacheNonPositiveTTLMeansNoExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := newTestRistrettoCache[string](t)
+
+	for _, ttl := range []time.Duration{0, -1, -time.Hour} {
+		if err := c.Set(ctx, "key", "value", ttl); err != nil {
+			t.Fatalf("Set(ttl=%s): %v", ttl, err)
+		}
+		if err := c.BatchSet(ctx, map[string]string{"a": "1", "b": "2"}, ttl); err != nil {
+			t.Fatalf("BatchSet(ttl=%s): %v", ttl, err)
+		}
+		if err := c.SetNotFound(ctx, "missing", ttl); err != nil {
+			t.Fatalf("SetNotFound(ttl=%s): %v", ttl, err)
+		}
+
+		// ristretto keeps its own time, so the entries are checked for having no expiry at all
+		for _, key := range []string{"key", "a", "b", "missing"} {
+			if _, err := c.GetTTL(ctx, key); !errors.Is(err, ErrNoExpiry) {
+				t.Errorf("GetTTL(%q) after ttl=%s = %v, want ErrNoExpiry", key, ttl, err)
+			}
+		}
+		if got, err := c.Get(ctx, "key"); err != nil || got != "value" {
+			t.Errorf("Get after ttl=%s = %q, %v; want %q, nil", ttl, got, err, "value")
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// s3ExpiresAtKey is the user metadata key holding an object's expiry time in Unix milliseconds
+const s3ExpiresAtKey = "cache-expires-at"
+
+// S3Client defines the subset of the S3 API used by S3Cache
+// *s3.Client from aws-sdk-go-v2 satisfies it
+type S3Client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// S3Cache implements the Cacher interface with objects stored in an S3 bucket
+// It is meant as the deepest tier for large, rarely read values that are too big for Redis
+// but expensive to recompute. Each key is stored as one object under Prefix.
+// The expiry time is stored in the object metadata and checked on read, since S3 has no per-object TTL;
+// configure a lifecycle expiration rule on the prefix to remove expired objects from the bucket
+type S3Cache[V any] struct {
+	client      S3Client
+	bucket      string
+	prefix      string
+	coder       Coder[V]
+	compression ByteTransform
+}
+
+// S3CacheConfig holds configuration for S3Cache
+type S3CacheConfig struct {
+	// Client is the S3 client used for all requests (required), typically *s3.Client
+	Client S3Client
+
+	// Bucket is the name of the bucket holding the cache objects (required)
+	Bucket string
+
+	// Prefix is prepended to every key to form the object key (e.g., "cache/reports/")
+	Prefix string
+
+	// Compression compresses encoded values before upload when set (e.g., a ZstdTransform).
+	// Objects written without compression can still be read after enabling it,
+	// as long as the transform passes unmarked data through unchanged.
+	Compression ByteTransform
+}
+
+// DefaultS3CacheConfig returns a default configuration
+// Client and Bucket must be set before use
+func DefaultS3CacheConfig() *S3CacheConfig {
+	return &S3CacheConfig{
+		Client:      nil,
+		Bucket:      "",
+		Prefix:      "cache/",
+		Compression: nil, // no compression
+	}
+}
+
+// NewS3Cache creates a new S3Cache instance
+// If coder is nil, JSONCoder is used
+func NewS3Cache[V any](config *S3CacheConfig, coder Coder[V]) (*S3Cache[V], error) {
+	if config == nil {
+		config = DefaultS3CacheConfig()
+	}
+	if config.Client == nil {
+		return nil, errors.New("s3 cache: client is required")
+	}
+	if config.Bucket == "" {
+		return nil, errors.New("s3 cache: bucket is required")
+	}
+	if coder == nil {
+		coder = NewJSONCoder[V]()
+	}
+	return &S3Cache[V]{
+		client:      config.Client,
+		bucket:      config.Bucket,
+		prefix:      config.Prefix,
+		coder:       coder,
+		compression: config.Compression,
+	}, nil
+}
+
+// Get retrieves a value from S3
+// Returns ErrCacheMiss if the object does not exist or has expired
+func (c *S3Cache[V]) Get(ctx context.Context, key string) (V, error) {
+	var zero V
+
+	out, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(c.objectKey(key)),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return zero, ErrCacheMiss
+		}
+		return zero, err
+	}
+	defer out.Body.Close()
+
+	if s3Expired(out.Metadata, time.Now()) {
+		return zero, ErrCacheMiss
+	}
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return zero, err
+	}
+	if c.compression != nil {
+		if data, err = c.compression.Invert(data); err != nil {
+			return zero, err
+		}
+	}
+	return c.coder.Decode(data)
+}
+
+// Set stores a value in S3 as a single object
+// If ttl is zero or negative, the object never expires
+func (c *S3Cache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	data, err := c.coder.Encode(value)
+	if err != nil {
+		return err
+	}
+	if c.compression != nil {
+		if data, err = c.compression.Transform(data); err != nil {
+			return err
+		}
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(c.bucket),
+		Key:           aws.String(c.objectKey(key)),
+		Body:          bytes.NewReader(data),
+		ContentLength: aws.Int64(int64(len(data))),
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		input.Metadata = map[string]string{
+			s3ExpiresAtKey: strconv.FormatInt(expiresAt.UnixMilli(), 10),
+		}
+		input.Expires = aws.Time(expiresAt)
+	}
+	_, err = c.client.PutObject(ctx, input)
+	return err
+}
+
+// Delete removes a value from S3 with DeleteObject
+// S3 does not report whether the object existed, so a missing key is not reported as ErrCacheMiss
+func (c *S3Cache[V]) Delete(ctx context.Context, key string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(c.objectKey(key)),
+	})
+	return err
+}
+
+// objectKey returns the S3 object key for a cache key
+func (c *S3Cache[V]) objectKey(key string) string {
+	return c.prefix + key
+}
+
+// isS3NotFound reports whether err is S3's response for a missing object
+func isS3NotFound(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "NoSuchKey", "NotFound":
+		return true
+	}
+	return false
+}
+
+// s3Expired reports whether the expiry time stored in the object metadata is at or before now
+// Objects without a valid expiry time never expire
+func s3Expired(metadata map[string]string, now time.Time) bool {
+	raw, ok := metadata[s3ExpiresAtKey]
+	if !ok {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return false
+	}
+	return now.UnixMilli() >= expiresAt
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"bytes"
+
+	"github.com/golang/snappy"
+)
+
+// snappyMagic prefixes Snappy-compressed payloads so Decode can tell them apart from uncompressed data
+var snappyMagic = []byte{0xff, 'S'}
+
+// SnappyCoder implements Coder by compressing the output of an inner Coder with Snappy
+// Snappy favors speed over compression ratio, which suits high-throughput paths
+// Payloads without the Snappy marker are passed to the inner Coder as-is, so uncompressed legacy data still decodes
+type SnappyCoder[V any] struct {
+	inner     Coder[V]
+	transform *SnappyTransform
+}
+
+// SnappyConfig holds configuration for SnappyCoder and SnappyTransform
+type SnappyConfig struct {
+	// MinCompressBytes is the size below which data is stored uncompressed, with a marker telling
+	// Decode it is not compressed (0 = compress everything).
+	// Compressing tiny values costs CPU and can even grow them.
+	MinCompressBytes int
+}
+
+// DefaultSnappyConfig returns a default configuration
+func DefaultSnappyConfig() *SnappyConfig {
+	return &SnappyConfig{
+		MinCompressBytes: 0, // compress everything
+	}
+}
+
+// NewSnappyCoder creates a new SnappyCoder instance wrapping the given Coder
+// If inner is nil, JSONCoder is used
+func NewSnappyCoder[V any](inner Coder[V]) *SnappyCoder[V] {
+	return NewSnappyCoderWithConfig(inner, nil)
+}
+
+// NewSnappyCoderWithConfig creates a new SnappyCoder instance wrapping the given Coder with the given configuration
+// If inner is nil, JSONCoder is used. If config is nil, DefaultSnappyConfig is used
+func NewSnappyCoderWithConfig[V any](inner Coder[V], config *SnappyConfig) *SnappyCoder[V] {
+	if inner == nil {
+		inner = NewJSONCoder[V]()
+	}
+	return &SnappyCoder[V]{
+		inner:     inner,
+		transform: NewSnappyTransformWithConfig(config),
+	}
+}
+
+// Encode serializes a value with the inner Coder and compresses it with Snappy
+func (c *SnappyCoder[V]) Encode(value V) ([]byte, error) {
+	data, err := c.inner.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	return c.transform.Transform(data)
+}
+
+// Decode decompresses Snappy bytes and deserializes them with the inner Coder
+func (c *SnappyCoder[V]) Decode(data []byte) (V, error) {
+	decoded, err := c.transform.Invert(data)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return c.inner.Decode(decoded)
+}
+
+// SnappyTransform implements ByteTransform using Snappy compression
+// It can be combined with other transforms in a ChainCoder
+type SnappyTransform struct {
+	minCompressBytes int
+}
+
+// NewSnappyTransform creates a new SnappyTransform instance
+func NewSnappyTransform() *SnappyTransform {
+	return NewSnappyTransformWithConfig(nil)
+}
+
+// NewSnappyTransformWithConfig creates a new SnappyTransform instance with the given configuration
+// If config is nil, DefaultSnappyConfig is used
+func NewSnappyTransformWithConfig(config *SnappyConfig) *SnappyTransform {
+	if config == nil {
+		config = DefaultSnappyConfig()
+	}
+	return &SnappyTransform{
+		minCompressBytes: config.MinCompressBytes,
+	}
+}
+
+// Transform compresses data with Snappy and prefixes it with the Snappy marker
+// Data shorter than MinCompressBytes is prefixed with the uncompressed marker instead
+func (t *SnappyTransform) Transform(data []byte) ([]byte, error) {
+	if len(data) < t.minCompressBytes {
+		return markUncompressed(data), nil
+	}
+	out := make([]byte, len(snappyMagic), len(snappyMagic)+snappy.MaxEncodedLen(len(data)))
+	copy(out, snappyMagic)
+	return append(out, snappy.Encode(nil, data)...), nil
+}
+
+// Invert decompresses data with Snappy
+// Data with the uncompressed marker is returned without it, and other data without the Snappy marker is returned unchanged
+func (t *SnappyTransform) Invert(data []byte) ([]byte, error) {
+	if bytes.HasPrefix(data, uncompressedMagic) {
+		return data[len(uncompressedMagic):], nil
+	}
+	if !bytes.HasPrefix(data, snappyMagic) {
+		return data, nil
+	}
+	return snappy.Decode(nil, data[len(snappyMagic):])
+}
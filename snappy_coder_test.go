@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benchRecord is a representative cached struct: a few scalars, free text and a small collection
+type benchRecord struct {
+	ID        int64
+	Name      string
+	Email     string
+	Bio       string
+	Tags      []string
+	Score     float64
+	Active    bool
+	CreatedAt time.Time
+}
+
+// newBenchRecord returns a benchRecord of roughly 1 KiB when encoded
+func newBenchRecord() benchRecord {
+	return benchRecord{
+		ID:        42,
+		Name:      "Jane Doe",
+		Email:     "jane.doe@example.com",
+		Bio:       strings.Repeat("Enjoys hiking, photography and distributed systems. ", 16),
+		Tags:      []string{"admin", "beta", "newsletter", "premium", "ja-JP"},
+		Score:     98.6,
+		Active:    true,
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestSnappyCoderRoundTrip(t *testing.T) {
+	for _, minBytes := range []int{0, 1 << 20} {
+		t.Run(fmt.Sprintf("MinCompressBytes=%d", minBytes), func(t *testing.T) {
+			coder := NewSnappyCoderWithConfig[benchRecord](NewMessagePackCoder[benchRecord](), &SnappyConfig{MinCompressBytes: minBytes})
+			want := newBenchRecord()
+
+			data, err := coder.Encode(want)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			got, err := coder.Decode(data)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Decode = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestSnappyCoderDecodesUncompressedLegacyData(t *testing.T) {
+	inner := NewMessagePackCoder[benchRecord]()
+	want := newBenchRecord()
+	legacy, err := inner.Encode(want)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := NewSnappyCoder[benchRecord](inner).Decode(legacy)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode = %+v, want %+v", got, want)
+	}
+}
+
+// BenchmarkMessagePackSnappy compares raw MessagePack with MessagePack compressed by Snappy
+// The encoded size is reported as encoded-bytes so throughput can be weighed against the bytes sent to a backend
+func BenchmarkMessagePackSnappy(b *testing.B) {
+	benchmarkCoders(b, newBenchRecord(), []namedCoder[benchRecord]{
+		{"MessagePack", NewMessagePackCoder[benchRecord]()},
+		{"MessagePack+Snappy", NewSnappyCoder[benchRecord](NewMessagePackCoder[benchRecord]())},
+	})
+}
+
+// namedCoder is a coder under benchmark
+type namedCoder[V any] struct {
+	name  string
+	coder Coder[V]
+}
+
+// benchmarkCoders runs encode and decode benchmarks of value for each coder
+func benchmarkCoders[V any](b *testing.B, value V, coders []namedCoder[V]) {
+	for _, c := range coders {
+		data, err := c.coder.Encode(value)
+		if err != nil {
+			b.Fatalf("%s: Encode: %v", c.name, err)
+		}
+
+		b.Run(c.name+"/Encode", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := c.coder.Encode(value); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(data)), "encoded-bytes")
+		})
+		b.Run(c.name+"/Decode", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := c.coder.Decode(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// GetStale retrieves a value using stale-while-revalidate semantics:
+// 1. If the value is younger than softTTL, return it
+// 2. If the value is older than softTTL but younger than hardTTL, return it immediately
+// and refresh it in the background by executing computeFn and populating all tiers
+// 3. If the value is missing or older than hardTTL, execute computeFn and populate all tiers before returning
+// tc is a TieredCache of Envelope values, so every tier (and its Coder, e.g., EnvelopeCoder)
+// persists the stored-at time and softTTL alongside the value.
+// Entries are stored with hardTTL. Background refreshes are deduplicated per key with singleflight
+// and run with a context detached from ctx's cancellation
+func GetStale[V any](ctx context.Context, tc *TieredCache[Envelope[V]], key string, softTTL, hardTTL time.Duration, computeFn ComputeFunc[V]) (V, error) {
+	var zero V
+
+	entryFn := func(ctx context.Context, key string) (Envelope[V], error) {
+		val, err := computeFn(ctx, key)
+		if err != nil {
+			return Envelope[V]{}, err
+		}
+		return NewEnvelope(val, softTTL, tc.clock.Now()), nil
+	}
+
+	entry, _, found, err := tc.getCache(ctx, key)
+	if err != nil {
+		return zero, err
+	}
+	if found {
+		age := entry.Age(tc.clock.Now())
+		if age < softTTL {
+			return entry.Value, nil
+		}
+		if age < hardTTL {
+			// Stale: serve immediately and refresh in the background
+			tc.refreshInBackground(ctx, key, hardTTL, entryFn)
+			return entry.Value, nil
+		}
+	}
+
+	// Missing or expired, compute synchronously with singleflight
+	entry, err = tc.compute(ctx, key, hardTTL, nil, entryFn)
+	if err != nil {
+		return zero, err
+	}
+	return entry.Value, nil
+}
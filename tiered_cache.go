@@ -3,26 +3,248 @@ package cache
 import (
 	"context"
 	"errors"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"sync"
 	"time"
 
+	"github.com/cespare/xxhash/v2"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/singleflight"
 )
 
 // ComputeFunc is a function that computes the value when cache misses occur
+// ctx is derived from the ctx passed to Get, so it carries the same values (e.g., metadata attached
+// with WithMetadata), bounded by ComputeTimeout if configured. With CoalesceShared, the ctx is
+// the one of the caller that started the compute; see CoalescingMode
 type ComputeFunc[V any] func(ctx context.Context, key string) (V, error)
 
+// TTLFunc derives the TTL of a computed value from how long the compute function took and the value,
+// e.g., to cache the results of slow queries longer than cheap ones
+type TTLFunc[V any] func(key string, computeDuration time.Duration, value V) time.Duration
+
+// PopulateFunc computes the value of a key like ComputeFunc, and additionally returns values of related keys
+// that the same computation yields (e.g., fetching a user also yields the email -> user mapping), so they can
+// be cached without computing them separately. extras may be nil
+type PopulateFunc[V any] func(ctx context.Context, key string) (value V, extras map[string]V, err error)
+
+// ZeroValuePolicy controls how TieredCache handles a compute function returning the zero value of V
+// (e.g., a nil pointer) without an error
+type ZeroValuePolicy int
+
+const (
+	// ZeroValueCache caches and returns the zero value like any other value, so later Get calls
+	// return it successfully, e.g., a nil *User, which can mask a "not found"
+	ZeroValueCache ZeroValuePolicy = iota
+	// ZeroValueSkip returns the zero value without caching it, so the next Get computes again
+	ZeroValueSkip
+	// ZeroValueNotFound treats the zero value as ErrNotFound, storing a negative cache entry if NegativeTTL is set
+	ZeroValueNotFound
+)
+
+// CoalescingMode controls how concurrent Get calls missing the same key share the compute function
+type CoalescingMode int
+
+const (
+	// CoalesceShared runs the compute function once with singleflight, using the ctx of the caller
+	// that started it (the leader). The other callers wait for and share its result, so a leader whose
+	// ctx is cancelled or carries another tenant's metadata affects them too
+	CoalesceShared CoalescingMode = iota
+	// CoalescePerCaller runs the compute function for every caller with its own ctx and no coalescing,
+	// trading stampede protection for strict per-request context isolation
+	CoalescePerCaller
+)
+
+// BackfillPolicy decides whether a tier is backfilled with a value found in a lower tier
+// foundTier is the index of the tier the value was found in (0 = L1, 1 = L2, etc.)
+type BackfillPolicy func(key string, foundTier int) bool
+
 // TieredCache implements a multi-tier caching strategy
 // Strategy: caches[0] (L1) → caches[1] (L2) → ... → caches[n] (Ln)
 // Uses singleflight to prevent cache stampede on compute function execution
+// Each TieredCache owns its singleflight group (or SingleflightShards groups) unless SingleflightGroup
+// is configured, so computes are only coalesced between Get calls on the same instance
 type TieredCache[V any] struct {
-	caches  []Cacher[V]
-	sfGroup singleflight.Group
+	caches         []Cacher[V]
+	sfGroups       []*singleflight.Group
+	sfPrefix       string
+	negativeTTL    time.Duration
+	computeTimeout time.Duration
+	refreshAhead   float64
+	failOpen       bool
+	onTierError    func(tierIndex int, key string, err error)
+	backfill       []BackfillPolicy
+	onBackfillErr  func(key string, err error)
+	warmChunkSize  int
+	refreshJitter  time.Duration
+	parallelProbe  bool
+	clock          Clock
+	coalescing     CoalescingMode
+	zeroValues     ZeroValuePolicy
+
+	backfillQueue     chan backfillTask[V]
+	backfillWG        sync.WaitGroup
+	backfillMu        sync.RWMutex
+	backfillClosed    bool
+	onBackfillDropped func(key string)
+
+	refreshMu      sync.Mutex
+	refreshPending map[string]struct{} // keys with a jittered background refresh waiting or running
+}
+
+// backfillTask is a backfill queued for the background workers
+type backfillTask[V any] struct {
+	ctx       context.Context
+	key       string
+	value     V
+	ttl       time.Duration
+	foundTier int
+}
+
+// TieredCacheConfig holds configuration for TieredCache
+type TieredCacheConfig struct {
+	// NegativeTTL enables negative caching when positive.
+	// When the compute function returns ErrNotFound, a negative cache entry is stored with this TTL
+	// in every tier implementing NegativeCacher, and subsequent Get calls return ErrNotFound
+	// without executing the compute function. Typically shorter than the regular TTL.
+	NegativeTTL time.Duration
+
+	// ComputeTimeout bounds the execution of the compute function when positive.
+	// The compute function receives a context that is cancelled after this duration,
+	// and callers waiting on it receive context.DeadlineExceeded. The singleflight entry
+	// is released on timeout so a subsequent Get can retry instead of inheriting the stuck call.
+	ComputeTimeout time.Duration
+
+	// RefreshAhead enables refresh-ahead when positive, as a fraction of the TTL (e.g., 0.2).
+	// When Get finds a value whose remaining TTL is below RefreshAhead * ttl, it returns the value
+	// and recomputes it in the background, resetting its TTL in all tiers. The remaining TTL is read
+	// from the tier the value was found in, so that tier must implement TTLer.
+	RefreshAhead float64
+
+	// SingleflightGroup shares a singleflight group between TieredCache instances (optional).
+	// If nil, the instance uses its own group and never coalesces computes with other instances.
+	// When a group is shared, computes for the same singleflight key are coalesced across instances,
+	// so instances with different value types or compute semantics must use distinct SingleflightPrefix values.
+	SingleflightGroup *singleflight.Group
+
+	// SingleflightPrefix is prepended to the cache key to form the singleflight key (optional).
+	// It scopes coalescing within a shared SingleflightGroup, e.g., "user:" and "order:" keep
+	// a user and an order with the same key "123" from sharing a compute.
+	SingleflightPrefix string
+
+	// SingleflightShards spreads keys over this many singleflight groups, selected by a hash of the
+	// singleflight key (default: 1). Each group serializes its bookkeeping on one mutex, so sharding
+	// reduces contention at very high concurrency and key cardinality; computes for the same key are
+	// still coalesced. Ignored when SingleflightGroup is set.
+	SingleflightShards int
+
+	// FailOpen makes Get treat tier errors like misses when true.
+	// A tier that fails to read is skipped in favor of the next tier or the compute function,
+	// and failures to write the computed value (or negative cache entry) do not fail the Get.
+	// When false, any tier error other than a miss aborts the Get.
+	FailOpen bool
+
+	// OnTierError is called for each tier error tolerated in FailOpen mode (optional), e.g., to log it.
+	// tierIndex is 0 for L1, 1 for L2, etc.
+	OnTierError func(tierIndex int, key string, err error)
+
+	// BackfillPolicies holds an optional BackfillPolicy per tier, indexed like the tiers (0 = L1).
+	// When a value is found in a lower tier, each tier above it is backfilled unless its policy
+	// returns false. Tiers without a policy (nil or beyond the slice) are always backfilled.
+	// Useful to keep one-shot keys from a scan out of a small L1.
+	BackfillPolicies []BackfillPolicy
+
+	// OnBackfillError is called when backfilling an upper tier fails or the write is dropped
+	// (ErrSetDropped) (optional). Backfill stays best-effort and the read still succeeds;
+	// the hook gives visibility into persistent failures, e.g., a misconfigured L1 rejecting writes.
+	OnBackfillError func(key string, err error)
+
+	// WarmChunkSize is the maximum number of items written per BatchSet call by Warm (default: 500).
+	// Smaller chunks keep pipelines to remote tiers small.
+	WarmChunkSize int
+
+	// RefreshJitter delays each background refresh (refresh-ahead and GetStale) by a random duration
+	// in [0, RefreshJitter) when positive, so instances that notice the same expiring key at the same time
+	// do not all hit the lower tiers and the compute function in lockstep. The refresh stays deduplicated
+	// per key during the delay.
+	RefreshJitter time.Duration
+
+	// ParallelProbe makes Get query all tiers concurrently instead of one after another.
+	// The first tier to return a value wins and the remaining reads are cancelled, so a cold key costs
+	// roughly the latency of the slowest miss instead of the sum of all misses, at the price of extra
+	// load on the lower tiers. On a miss in every tier the compute function still runs once per key.
+	ParallelProbe bool
+
+	// BackfillQueueSize enables asynchronous backfill when positive.
+	// Backfills of upper tiers after a lower-tier hit are queued and applied by background workers,
+	// so hot keys do not add write contention to the read path. When the queue is full the backfill
+	// is dropped and reported to OnBackfillDropped instead of blocking the read.
+	// If zero or negative, backfill runs synchronously in Get.
+	BackfillQueueSize int
+
+	// BackfillWorkers is the number of goroutines applying queued backfills (default: 1).
+	// Only used when BackfillQueueSize is positive.
+	BackfillWorkers int
+
+	// OnBackfillDropped is called with the key of each backfill dropped because the queue was full (optional).
+	// Typically used to increment a metric.
+	OnBackfillDropped func(key string)
+
+	// Clock is the time source for envelope timestamps and staleness in GetStale, for refresh delays,
+	// for the ComputeTimeout deadline and for the flush interval of a WriteBackTieredCache (default: the system clock).
+	Clock Clock
+
+	// Coalescing controls whether concurrent Get calls missing the same key share one compute
+	// started with the first caller's ctx (CoalesceShared, default) or each compute with their own ctx
+	// (CoalescePerCaller). Background refreshes are always coalesced.
+	Coalescing CoalescingMode
+
+	// ZeroValuePolicy controls computed zero values (e.g., a nil pointer returned with a nil error):
+	// ZeroValueCache (default) caches them, ZeroValueSkip returns them without caching,
+	// and ZeroValueNotFound reports them as ErrNotFound.
+	ZeroValuePolicy ZeroValuePolicy
+}
+
+// DefaultTieredCacheConfig returns a default configuration
+func DefaultTieredCacheConfig() *TieredCacheConfig {
+	return &TieredCacheConfig{
+		NegativeTTL:        0,   // negative caching disabled
+		ComputeTimeout:     0,   // no timeout
+		RefreshAhead:       0,   // refresh-ahead disabled
+		SingleflightGroup:  nil, // per-instance group
+		SingleflightPrefix: "",
+		SingleflightShards: 1,
+		FailOpen:           false,
+		OnTierError:        nil,
+		BackfillPolicies:   nil, // backfill every upper tier
+		OnBackfillError:    nil,
+		WarmChunkSize:      defaultWarmChunkSize,
+		RefreshJitter:      0, // no jitter
+		ParallelProbe:      false,
+		BackfillQueueSize:  0, // synchronous backfill
+		BackfillWorkers:    1,
+		OnBackfillDropped:  nil,
+		Clock:              RealClock(),
+		Coalescing:         CoalesceShared,
+		ZeroValuePolicy:    ZeroValueCache,
+	}
 }
 
 // NewTieredCache creates a new multi-tier cache with dependency injection
 // caches is a slice where caches[0] is L1 (fastest), caches[1] is L2, etc.
 // Empty or nil caches in the slice are skipped
 func NewTieredCache[V any](caches ...Cacher[V]) *TieredCache[V] {
+	return NewTieredCacheWithConfig(nil, caches...)
+}
+
+// NewTieredCacheWithConfig creates a new multi-tier cache with the given configuration
+// If config is nil, DefaultTieredCacheConfig is used
+func NewTieredCacheWithConfig[V any](config *TieredCacheConfig, caches ...Cacher[V]) *TieredCache[V] {
+	if config == nil {
+		config = DefaultTieredCacheConfig()
+	}
 	// Filter out nil caches
 	validCaches := make([]Cacher[V], 0, len(caches))
 	for _, cache := range caches {
@@ -30,103 +252,731 @@ func NewTieredCache[V any](caches ...Cacher[V]) *TieredCache[V] {
 			validCaches = append(validCaches, cache)
 		}
 	}
-	return &TieredCache[V]{
-		caches: validCaches,
+	sfGroups := []*singleflight.Group{config.SingleflightGroup}
+	if config.SingleflightGroup == nil {
+		sfGroups = make([]*singleflight.Group, max(config.SingleflightShards, 1))
+		for i := range sfGroups {
+			sfGroups[i] = &singleflight.Group{}
+		}
 	}
+	tc := &TieredCache[V]{
+		caches:         validCaches,
+		sfGroups:       sfGroups,
+		sfPrefix:       config.SingleflightPrefix,
+		negativeTTL:    config.NegativeTTL,
+		computeTimeout: config.ComputeTimeout,
+		refreshAhead:   config.RefreshAhead,
+		failOpen:       config.FailOpen,
+		onTierError:    config.OnTierError,
+		backfill:       config.BackfillPolicies,
+		onBackfillErr:  config.OnBackfillError,
+		warmChunkSize:  config.WarmChunkSize,
+		refreshJitter:  config.RefreshJitter,
+		parallelProbe:  config.ParallelProbe,
+		clock:          clockOrReal(config.Clock),
+		coalescing:     config.Coalescing,
+		zeroValues:     config.ZeroValuePolicy,
+
+		onBackfillDropped: config.OnBackfillDropped,
+		refreshPending:    make(map[string]struct{}),
+	}
+	if config.BackfillQueueSize > 0 {
+		workers := config.BackfillWorkers
+		if workers <= 0 {
+			workers = 1
+		}
+		tc.backfillQueue = make(chan backfillTask[V], config.BackfillQueueSize)
+		tc.backfillWG.Add(workers)
+		for range workers {
+			go tc.backfillWorker()
+		}
+	}
+	return tc
 }
 
 // Get retrieves a value using the tiered caching strategy with compute function:
 // 1. Check L1, L2, ..., Ln in order
-// 2. If found in Li (i > 0), populate upper tiers (L0 to Li-1)
+// 2. If found in Li (i > 0), populate upper tiers (L1 to Li-1) allowed by their BackfillPolicy
 // 3. If not found in any tier, execute computeFn and populate all tiers
-// Uses singleflight to ensure only one compute function executes per key concurrently
+// Uses singleflight to ensure only one compute function executes per key concurrently, with the ctx
+// of the caller that started it, unless Coalescing is CoalescePerCaller
+// Errors from the compute function satisfy errors.Is(err, ErrComputeFailed) and errors from cache tiers
+// satisfy errors.Is(err, ErrCacheBackend); the original error is available via errors.Unwrap.
+// ErrNotFound is returned as-is since it is a negative result rather than a failure
+// If negative caching is enabled and computeFn returns ErrNotFound, a negative cache entry is stored
+// and ErrNotFound is returned until it expires
 func (tc *TieredCache[V]) Get(ctx context.Context, key string, ttl time.Duration, computeFn ComputeFunc[V]) (V, error) {
+	return tc.GetWithTTLFunc(ctx, key, ttl, nil, computeFn)
+}
+
+// GetWithTTLFunc retrieves a value like Get, deriving the TTL of a computed value with ttlFn
+// The compute duration passed to ttlFn is measured around computeFn. If ttlFn is nil, ttl is used as in Get.
+// ttl is still used for backfills and refresh-ahead, where no compute duration is known
+func (tc *TieredCache[V]) GetWithTTLFunc(ctx context.Context, key string, ttl time.Duration, ttlFn TTLFunc[V], computeFn ComputeFunc[V]) (V, error) {
 	var zero V
 
 	// Try to get from cache tiers
-	val, _, found, err := tc.getCache(ctx, key)
+	val, tierIndex, found, err := tc.getCache(ctx, key)
 	if err != nil {
 		return zero, err
 	}
 	if found {
-		// TODO: Populate upper tiers if found in L2 or below
+		tc.backfillUpperTiers(ctx, key, val, ttl, tierIndex)
+		tc.refreshAheadIfExpiring(ctx, key, ttl, tierIndex, computeFn)
 		return val, nil
 	}
 
 	// All caches missed, execute compute function with singleflight
-	result, err, _ := tc.sfGroup.Do(key, func() (interface{}, error) {
-		// TODO: Double-check cache after acquiring singleflight lock?
+	return tc.compute(ctx, key, ttl, ttlFn, computeFn)
+}
 
-		// Execute compute function
-		val, err = computeFn(ctx, key)
+// compute executes computeFn with singleflight and stores the result in all cache tiers
+// If a compute timeout is configured, waiting is bounded by it and the singleflight entry
+// is forgotten on timeout so later calls start a fresh compute
+// With CoalescePerCaller, computeFn runs with ctx directly instead
+// If ttlFn is not nil, it derives the TTL of the computed value instead of ttl
+func (tc *TieredCache[V]) compute(ctx context.Context, key string, ttl time.Duration, ttlFn TTLFunc[V], computeFn ComputeFunc[V]) (V, error) {
+	res, err := tc.computePopulate(ctx, key, ttl, ttlFn, populateOnly(computeFn))
+	return res.value, err
+}
+
+// computePopulate executes populateFn like compute executes a compute function, and also stores the extras
+// The result of a joined compute carries the extras error of that compute, so every caller sharing it
+// sees the same outcome
+func (tc *TieredCache[V]) computePopulate(ctx context.Context, key string, ttl time.Duration, ttlFn TTLFunc[V], populateFn PopulateFunc[V]) (computeResult[V], error) {
+	if tc.coalescing == CoalescePerCaller {
+		result, err := tc.computeAndSet(ctx, key, ttl, ttlFn, populateFn)()
 		if err != nil {
-			return zero, err
+			return computeResult[V]{}, err
 		}
-		// Set in all caches
-		if err := tc.setCache(ctx, key, val, ttl); err != nil {
-			return zero, err
+		return result.(computeResult[V]), nil
+	}
+
+	sfKey := tc.sfKey(key)
+	if tc.computeTimeout <= 0 {
+		result, err, _ := tc.sfGroup(sfKey).Do(sfKey, tc.computeAndSet(ctx, key, ttl, ttlFn, populateFn))
+		if err != nil {
+			return computeResult[V]{}, err
+		}
+		return result.(computeResult[V]), nil
+	}
+
+	ch := tc.sfGroup(sfKey).DoChan(sfKey, tc.computeAndSet(ctx, key, ttl, ttlFn, populateFn))
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return computeResult[V]{}, res.Err
 		}
+		return res.Val.(computeResult[V]), nil
+	case <-tc.clock.After(tc.computeTimeout):
+		tc.sfGroup(sfKey).Forget(sfKey)
+		return computeResult[V]{}, &kindError{kind: ErrComputeFailed, err: context.DeadlineExceeded}
+	}
+}
+
+// computeResult is the singleflight result of a successful compute
+type computeResult[V any] struct {
+	value V
+
+	// extrasErr is the error storing the extras returned by a PopulateFunc, if any
+	extrasErr error
+}
+
+// populateOnly adapts a ComputeFunc to a PopulateFunc returning no extras
+func populateOnly[V any](computeFn ComputeFunc[V]) PopulateFunc[V] {
+	return func(ctx context.Context, key string) (V, map[string]V, error) {
+		val, err := computeFn(ctx, key)
+		return val, nil, err
+	}
+}
+
+// GetAndPopulate retrieves a value like Get, computing it with populateFn on a miss
+// On a successful compute, the primary value is stored in all tiers first, then the extra values returned by
+// populateFn, all with ttl; the extras are written with BatchSet for tiers that support it. An extra for key
+// itself is ignored, and the extras are discarded if populateFn fails or the primary value cannot be stored.
+// Failures to store the extras are handled like failures to store the primary value: they fail the call with
+// ErrCacheBackend, or are reported to OnTierError in FailOpen mode. GetAndPopulate callers coalesced into the
+// same compute get the same result; Get callers joining it get the value and ignore the extras error
+func (tc *TieredCache[V]) GetAndPopulate(ctx context.Context, key string, ttl time.Duration, populateFn PopulateFunc[V]) (V, error) {
+	var zero V
+
+	val, tierIndex, found, err := tc.getCache(ctx, key)
+	if err != nil {
+		return zero, err
+	}
+	if found {
+		tc.backfillUpperTiers(ctx, key, val, ttl, tierIndex)
+		tc.refreshAheadIfExpiring(ctx, key, ttl, tierIndex, computeOnly(populateFn))
 		return val, nil
-	})
+	}
+
+	res, err := tc.computePopulate(ctx, key, ttl, nil, populateFn)
 	if err != nil {
 		return zero, err
 	}
-	return result.(V), nil
+	if res.extrasErr != nil {
+		return zero, &kindError{kind: ErrCacheBackend, err: res.extrasErr}
+	}
+	return res.value, nil
+}
+
+// computeOnly adapts a PopulateFunc to a ComputeFunc discarding the extras
+func computeOnly[V any](populateFn PopulateFunc[V]) ComputeFunc[V] {
+	return func(ctx context.Context, key string) (V, error) {
+		val, _, err := populateFn(ctx, key)
+		return val, err
+	}
+}
+
+// computeAndSet returns a singleflight function that executes populateFn and stores the result in all cache tiers
+// The result is stored with ttl, or with the TTL returned by ttlFn if it is not nil; the extras are stored
+// with ttl after it. The function returns a computeResult[V] on success
+func (tc *TieredCache[V]) computeAndSet(ctx context.Context, key string, ttl time.Duration, ttlFn TTLFunc[V], populateFn PopulateFunc[V]) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		var zero V
+
+		// The tiers are not re-checked here: background refreshes run this for keys that are still cached,
+		// and a miss racing a compute that just finished costs at most one extra compute
+
+		// Execute compute function, bounded by the compute timeout if configured
+		computeCtx := ctx
+		if tc.computeTimeout > 0 {
+			var cancel context.CancelFunc
+			computeCtx, cancel = context.WithTimeout(ctx, tc.computeTimeout)
+			defer cancel()
+		}
+		start := tc.clock.Now()
+		val, extras, err := populateFn(computeCtx, key)
+		computeDuration := tc.clock.Now().Sub(start)
+		if err == nil && tc.zeroValues == ZeroValueNotFound && isZeroValue(val) {
+			err = ErrNotFound
+		}
+		if err != nil {
+			if !errors.Is(err, ErrNotFound) {
+				return zero, &kindError{kind: ErrComputeFailed, err: err}
+			}
+			if tc.negativeTTL > 0 {
+				if err := tc.setNotFound(ctx, key); err != nil {
+					return zero, &kindError{kind: ErrCacheBackend, err: err}
+				}
+			}
+			return zero, err
+		}
+		if tc.zeroValues != ZeroValueSkip || !isZeroValue(val) {
+			valTTL := ttl
+			if ttlFn != nil {
+				valTTL = ttlFn(key, computeDuration, val)
+			}
+			// Set in all caches
+			if err := tc.fillCache(ctx, key, val, valTTL); err != nil {
+				return zero, &kindError{kind: ErrCacheBackend, err: err}
+			}
+		}
+		if _, ok := extras[key]; ok {
+			extras = maps.Clone(extras)
+			delete(extras, key)
+		}
+		res := computeResult[V]{value: val}
+		if len(extras) > 0 {
+			res.extrasErr = tc.fillCacheMany(ctx, key, extras, ttl)
+		}
+		return res, nil
+	}
+}
+
+// isZeroValue reports whether v is the zero value of its type, e.g., a nil pointer, map or slice
+func isZeroValue[V any](v V) bool {
+	return reflect.ValueOf(&v).Elem().IsZero()
+}
+
+// refreshAheadIfExpiring starts a background refresh when the value found in the given tier is close to expiry
+// Background refreshes are deduplicated per key with singleflight and run with a context
+// detached from ctx's cancellation, so they never block the read that triggered them
+func (tc *TieredCache[V]) refreshAheadIfExpiring(ctx context.Context, key string, ttl time.Duration, tierIndex int, computeFn ComputeFunc[V]) {
+	if tc.refreshAhead <= 0 || ttl <= 0 {
+		return
+	}
+	ttler, ok := tc.caches[tierIndex].(TTLer)
+	if !ok {
+		return
+	}
+	remaining, err := ttler.GetTTL(ctx, key)
+	if err != nil || remaining > time.Duration(float64(ttl)*tc.refreshAhead) {
+		return
+	}
+	tc.refreshInBackground(ctx, key, ttl, computeFn)
+}
+
+// refreshInBackground recomputes a value and stores it in all tiers without blocking the caller
+// The refresh is deduplicated per key with singleflight, runs with a context detached from ctx's cancellation,
+// and starts after a random delay of up to RefreshJitter
+// The delay is waited outside singleflight, so a Get missing the key meanwhile computes it instead of
+// waiting for the delay; the pending refresh is deduplicated per key until its compute finishes
+func (tc *TieredCache[V]) refreshInBackground(ctx context.Context, key string, ttl time.Duration, computeFn ComputeFunc[V]) {
+	refresh := tc.computeAndSet(context.WithoutCancel(ctx), key, ttl, nil, populateOnly(computeFn))
+	sfKey := tc.sfKey(key)
+	if tc.refreshJitter <= 0 {
+		// The result channel is buffered, so it is safe to drop it
+		tc.sfGroup(sfKey).DoChan(sfKey, refresh)
+		return
+	}
+
+	tc.refreshMu.Lock()
+	if _, pending := tc.refreshPending[key]; pending {
+		tc.refreshMu.Unlock()
+		return
+	}
+	tc.refreshPending[key] = struct{}{}
+	tc.refreshMu.Unlock()
+
+	delay := rand.N(tc.refreshJitter)
+	go func() {
+		defer func() {
+			tc.refreshMu.Lock()
+			delete(tc.refreshPending, key)
+			tc.refreshMu.Unlock()
+		}()
+		<-tc.clock.After(delay)
+		<-tc.sfGroup(sfKey).DoChan(sfKey, refresh)
+	}()
+}
+
+// sfKey returns the singleflight key for a cache key
+func (tc *TieredCache[V]) sfKey(key string) string {
+	return tc.sfPrefix + key
+}
+
+// sfGroup returns the singleflight group responsible for a singleflight key
+func (tc *TieredCache[V]) sfGroup(sfKey string) *singleflight.Group {
+	if len(tc.sfGroups) == 1 {
+		return tc.sfGroups[0]
+	}
+	return tc.sfGroups[xxhash.Sum64String(sfKey)%uint64(len(tc.sfGroups))]
 }
 
 // getCache attempts to retrieve a value from cache tiers
 // Returns (value, tierIndex, found, error)
 // tierIndex indicates which tier the value was found in (0 = L1, 1 = L2, etc.)
+// Tier errors other than ErrNotFound are wrapped with ErrCacheBackend, or skipped in fail-open mode
 func (tc *TieredCache[V]) getCache(ctx context.Context, key string) (V, int, bool, error) {
 	var zero V
 
+	if tc.parallelProbe && len(tc.caches) > 1 {
+		return tc.probeCache(ctx, key)
+	}
+
 	// Try each cache tier in order
 	for i, cache := range tc.caches {
 		val, err := cache.Get(ctx, key)
 		if err == nil {
 			return val, i, true, nil
 		}
-		if !errors.Is(err, ErrCacheMiss) {
+		if errors.Is(err, ErrNotFound) {
 			return zero, -1, false, err
 		}
+		if !errors.Is(err, ErrCacheMiss) {
+			if tc.failOpen {
+				tc.reportTierError(i, key, err)
+				continue
+			}
+			return zero, -1, false, &kindError{kind: ErrCacheBackend, err: err}
+		}
 	}
 
 	// Not found in any cache
 	return zero, -1, false, nil
 }
 
+// errProbeHit stops the remaining tier reads of a parallel probe once a tier returns a value
+var errProbeHit = errors.New("probe hit")
+
+// probeCache queries all cache tiers concurrently and returns the first value found
+// Once a tier returns a value, ErrNotFound or a backend error, the reads still in flight are cancelled
+// Results are otherwise interpreted the same way as getCache
+func (tc *TieredCache[V]) probeCache(ctx context.Context, key string) (V, int, bool, error) {
+	var (
+		zero     V
+		mu       sync.Mutex
+		val      V
+		hitIndex = -1
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, cache := range tc.caches {
+		g.Go(func() error {
+			v, err := cache.Get(gctx, key)
+			if err == nil {
+				mu.Lock()
+				if hitIndex < 0 {
+					val, hitIndex = v, i
+				}
+				mu.Unlock()
+				return errProbeHit
+			}
+			if errors.Is(err, ErrCacheMiss) {
+				return nil
+			}
+			if errors.Is(err, ErrNotFound) {
+				return err
+			}
+			if gctx.Err() != nil && ctx.Err() == nil {
+				// Cancelled because another tier already answered
+				return nil
+			}
+			if tc.failOpen {
+				tc.reportTierError(i, key, err)
+				return nil
+			}
+			return &kindError{kind: ErrCacheBackend, err: err}
+		})
+	}
+
+	// errgroup keeps the first non-nil error, which is the answer that ended the probe
+	err := g.Wait()
+	switch {
+	case errors.Is(err, errProbeHit):
+		mu.Lock()
+		defer mu.Unlock()
+		return val, hitIndex, true, nil
+	case err == nil:
+		return zero, -1, false, nil
+	default:
+		return zero, -1, false, err
+	}
+}
+
 // setCache writes a value to all cache tiers
+// Writes dropped by a tier (ErrSetDropped) are not treated as failures since caching is best-effort
 func (tc *TieredCache[V]) setCache(ctx context.Context, key string, value V, ttl time.Duration) error {
 	for _, cache := range tc.caches {
-		if err := cache.Set(ctx, key, value, ttl); err != nil {
+		if err := cache.Set(ctx, key, value, ttl); err != nil && !errors.Is(err, ErrSetDropped) {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillCache writes a computed value to all cache tiers
+// In fail-open mode, tier errors are reported and the remaining tiers are still written
+func (tc *TieredCache[V]) fillCache(ctx context.Context, key string, value V, ttl time.Duration) error {
+	if !tc.failOpen {
+		return tc.setCache(ctx, key, value, ttl)
+	}
+	for i, cache := range tc.caches {
+		if err := cache.Set(ctx, key, value, ttl); err != nil && !errors.Is(err, ErrSetDropped) {
+			tc.reportTierError(i, key, err)
+		}
+	}
+	return nil
+}
+
+// fillCacheMany writes computed values to all cache tiers in chunks of WarmChunkSize
+// In fail-open mode, tier errors are reported with key, the key the values were computed for,
+// and the remaining tiers are still written
+func (tc *TieredCache[V]) fillCacheMany(ctx context.Context, key string, items map[string]V, ttl time.Duration) error {
+	for i, cache := range tc.caches {
+		if err := warmCache(ctx, cache, items, ttl, tc.warmChunkSize); err != nil {
+			if tc.failOpen {
+				tc.reportTierError(i, key, err)
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// setNotFound writes a negative cache entry to all cache tiers that support it
+// In fail-open mode, tier errors are reported and the remaining tiers are still written
+func (tc *TieredCache[V]) setNotFound(ctx context.Context, key string) error {
+	for i, cache := range tc.caches {
+		nc, ok := cache.(NegativeCacher)
+		if !ok {
+			continue
+		}
+		err := nc.SetNotFound(ctx, key, tc.negativeTTL)
+		if err != nil && !errors.Is(err, ErrSetDropped) && !errors.Is(err, ErrUnsupported) {
+			if tc.failOpen {
+				tc.reportTierError(i, key, err)
+				continue
+			}
 			return err
 		}
 	}
 	return nil
 }
 
+// reportTierError passes a tier error tolerated in fail-open mode to OnTierError if configured
+func (tc *TieredCache[V]) reportTierError(tierIndex int, key string, err error) {
+	if tc.onTierError != nil {
+		tc.onTierError(tierIndex, key, err)
+	}
+}
+
 // Set stores a value in all cache tiers
 func (tc *TieredCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
 	return tc.setCache(ctx, key, value, ttl)
 }
 
 // Delete removes a key from all cache tiers
+// Missing keys are ignored; other errors do not stop the remaining deletes and are returned
+// as a *MultiError naming each failed tier (e.g., "L2: ...")
 func (tc *TieredCache[V]) Delete(ctx context.Context, key string) error {
-	for _, cache := range tc.caches {
+	var errs []error
+	for i, cache := range tc.caches {
 		if err := cache.Delete(ctx, key); err != nil && !errors.Is(err, ErrCacheMiss) {
+			errs = append(errs, tierError(i, err))
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// Refresh invalidates a key in all cache tiers, executes computeFn and stores the new value in all tiers,
+// e.g., right after the underlying entity changed
+// It runs as the key's singleflight compute, so Get calls missing the key meanwhile wait for Refresh instead of
+// computing separately. A compute already in flight may have read the source before it changed, so Refresh
+// waits for it to finish and store its value first, then deletes and recomputes; the stale value can therefore
+// never overwrite the refreshed one. If a compute that started after Refresh was called is in flight instead,
+// Refresh returns its value. Computes outside singleflight (CoalescePerCaller, or computes abandoned after
+// ComputeTimeout) cannot be waited for and may still overwrite the value; update the source of truth before
+// calling Refresh
+// Errors are classified like Get's
+func (tc *TieredCache[V]) Refresh(ctx context.Context, key string, ttl time.Duration, computeFn ComputeFunc[V]) (V, error) {
+	var zero V
+
+	ran := false
+	refresh := func() (interface{}, error) {
+		ran = true
+		if err := tc.Delete(ctx, key); err != nil {
+			return zero, &kindError{kind: ErrCacheBackend, err: err}
+		}
+		return tc.computeAndSet(ctx, key, ttl, nil, populateOnly(computeFn))()
+	}
+
+	sfKey := tc.sfKey(key)
+	group := tc.sfGroup(sfKey)
+	var res singleflight.Result
+	select {
+	case res = <-group.DoChan(sfKey, refresh):
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+	if !ran {
+		// Joined a compute that was in flight before Refresh; now that it has stored its possibly stale value,
+		// refresh again. This joins any compute started since, which read the updated source
+		res.Val, res.Err, _ = group.Do(sfKey, refresh)
+	}
+	if res.Err != nil {
+		return zero, res.Err
+	}
+	return res.Val.(computeResult[V]).value, nil
+}
+
+// DeleteExisting removes a key from all cache tiers and reports whether it was present in at least one of them
+// A tier reports a key as absent by returning ErrCacheMiss from Delete. Other errors do not stop the
+// remaining deletes and are returned as a *MultiError naming each failed tier
+func (tc *TieredCache[V]) DeleteExisting(ctx context.Context, key string) (bool, error) {
+	existed := false
+	var errs []error
+	for i, cache := range tc.caches {
+		err := cache.Delete(ctx, key)
+		if err == nil {
+			existed = true
+			continue
+		}
+		if !errors.Is(err, ErrCacheMiss) {
+			errs = append(errs, tierError(i, err))
+		}
+	}
+	return existed, newMultiError(errs...)
+}
+
+// Warm preloads items into all cache tiers, e.g., from a known dataset on startup
+// Items are written in chunks of WarmChunkSize, using BatchSet for tiers that support it.
+// Writes dropped by a tier (ErrSetDropped) are not treated as failures; other errors do not stop
+// warming the remaining tiers and are returned as a *MultiError naming each failed tier
+func (tc *TieredCache[V]) Warm(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	var errs []error
+	for i, cache := range tc.caches {
+		if err := warmCache(ctx, cache, items, ttl, tc.warmChunkSize); err != nil {
+			errs = append(errs, tierError(i, err))
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// DeleteMany removes multiple keys from all cache tiers
+// Uses each tier's BatchDelete when available and falls back to per-key Delete otherwise.
+// Missing keys are ignored; other errors do not stop the remaining deletes and are returned
+// as a *MultiError naming each failed tier
+func (tc *TieredCache[V]) DeleteMany(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	var errs []error
+	for i, cache := range tc.caches {
+		if bc, ok := cache.(BatchCacher[V]); ok {
+			if err := bc.BatchDelete(ctx, keys); err != nil {
+				errs = append(errs, tierError(i, err))
+			}
+			continue
+		}
+		for _, key := range keys {
+			if err := cache.Delete(ctx, key); err != nil && !errors.Is(err, ErrCacheMiss) {
+				errs = append(errs, tierError(i, err))
+			}
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// Clear removes all items from every cache tier that supports it
+// Tiers not implementing Clearer are skipped.
+// All tiers are cleared even if some fail, and the errors are returned as a *MultiError naming each failed tier
+func (tc *TieredCache[V]) Clear(ctx context.Context) error {
+	var errs []error
+	for i, cache := range tc.caches {
+		if err := clearCache(ctx, cache); err != nil {
+			errs = append(errs, tierError(i, err))
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// Close closes every cache tier implementing io.Closer
+// If asynchronous backfill is enabled, queued backfills are applied first
+// All tiers are closed even if some fail, and the errors are returned as a *MultiError naming each failed tier
+func (tc *TieredCache[V]) Close() error {
+	tc.drainBackfill()
+
+	var errs []error
+	for i, cache := range tc.caches {
+		if err := closeCache(cache); err != nil {
+			errs = append(errs, tierError(i, err))
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// HealthCheck pings every cache tier implementing Pinger
+// Returns nil if all tiers are healthy, otherwise a *MultiError naming each unhealthy tier (e.g., "L2: ...")
+// Each error matches ErrCacheUnavailable, or ctx.Err() if ctx ended before the tier answered
+func (tc *TieredCache[V]) HealthCheck(ctx context.Context) error {
+	var errs []error
+	for i, cache := range tc.caches {
+		if err := pingCache(ctx, i, cache); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// pingCache pings a cache if it implements Pinger
+// A decorator returning ErrUnsupported because the cache it wraps cannot be pinged is treated like a cache
+// without Pinger. The error is classified with classifyPingError and annotated with the tier name derived
+// from tierIndex (0 = L1)
+func pingCache(ctx context.Context, tierIndex int, c any) error {
+	pinger, ok := c.(Pinger)
+	if !ok {
+		return nil
+	}
+	err := pinger.Ping(ctx)
+	if errors.Is(err, ErrUnsupported) {
+		return nil
+	}
+	if err := classifyPingError(ctx, err); err != nil {
+		return tierError(tierIndex, err)
+	}
+	return nil
+}
+
+// closeCache closes a cache if it implements io.Closer
+// A decorator returning ErrUnsupported because the cache it wraps cannot be closed is treated like a cache
+// without io.Closer
+func closeCache(c any) error {
+	if closer, ok := c.(io.Closer); ok {
+		if err := closer.Close(); !errors.Is(err, ErrUnsupported) {
 			return err
 		}
 	}
 	return nil
 }
 
-// populateUpperTiers writes a value to all cache tiers above the specified tier
+// clearCache removes all items from a cache implementing Clearer
+// Caches that do not implement it are skipped
+func clearCache[V any](ctx context.Context, c Cacher[V]) error {
+	if clearer, ok := c.(Clearer); ok {
+		return clearer.Clear(ctx)
+	}
+	return nil
+}
+
+// backfillUpperTiers populates the upper tiers, synchronously or through the backfill queue if enabled
+// A queued backfill is dropped and reported to OnBackfillDropped if the queue is full or closed
+func (tc *TieredCache[V]) backfillUpperTiers(ctx context.Context, key string, value V, ttl time.Duration, foundTierIndex int) {
+	if foundTierIndex <= 0 {
+		return
+	}
+	if tc.backfillQueue == nil {
+		tc.populateUpperTiers(ctx, key, value, ttl, foundTierIndex)
+		return
+	}
+
+	tc.backfillMu.RLock()
+	defer tc.backfillMu.RUnlock()
+	if !tc.backfillClosed {
+		select {
+		case tc.backfillQueue <- backfillTask[V]{ctx: context.WithoutCancel(ctx), key: key, value: value, ttl: ttl, foundTier: foundTierIndex}:
+			return
+		default:
+		}
+	}
+	if tc.onBackfillDropped != nil {
+		tc.onBackfillDropped(key)
+	}
+}
+
+// backfillWorker applies queued backfills until the queue is closed
+func (tc *TieredCache[V]) backfillWorker() {
+	defer tc.backfillWG.Done()
+	for task := range tc.backfillQueue {
+		tc.populateUpperTiers(task.ctx, task.key, task.value, task.ttl, task.foundTier)
+	}
+}
+
+// drainBackfill closes the backfill queue and waits for the workers to apply the queued backfills
+func (tc *TieredCache[V]) drainBackfill() {
+	if tc.backfillQueue == nil {
+		return
+	}
+	tc.backfillMu.Lock()
+	if !tc.backfillClosed {
+		tc.backfillClosed = true
+		close(tc.backfillQueue)
+	}
+	tc.backfillMu.Unlock()
+	tc.backfillWG.Wait()
+}
+
+// populateUpperTiers writes a value to the cache tiers above the specified tier allowed by their BackfillPolicy
 // Used when a value is found in L2+ to populate L1
-// func (tc *TieredCache[V]) populateUpperTiers(ctx context.Context, key string, value V, ttl time.Duration, foundTierIndex int) error {
-// 	for i := 0; i < foundTierIndex && i < len(tc.caches); i++ {
-// 		if err := tc.caches[i].Set(ctx, key, value, ttl); err != nil {
-// 			return err
-// 		}
-// 	}
-// 	return nil
-// }
+// Backfill is best-effort, so write errors do not fail the read that found the value
+// and are only reported to OnBackfillError
+func (tc *TieredCache[V]) populateUpperTiers(ctx context.Context, key string, value V, ttl time.Duration, foundTierIndex int) {
+	for i := 0; i < foundTierIndex && i < len(tc.caches); i++ {
+		if !shouldBackfill(tc.backfill, key, foundTierIndex, i) {
+			continue
+		}
+		if err := tc.caches[i].Set(ctx, key, value, ttl); err != nil && tc.onBackfillErr != nil {
+			tc.onBackfillErr(key, err)
+		}
+	}
+}
+
+// shouldBackfill reports whether targetTier is backfilled with a value for key found in foundTier
+func shouldBackfill(policies []BackfillPolicy, key string, foundTier, targetTier int) bool {
+	if targetTier >= len(policies) || policies[targetTier] == nil {
+		return true
+	}
+	return policies[targetTier](key, foundTier)
+}
@@ -0,0 +1,301 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testUser is a cached struct stored by pointer, so a compute function can return a nil *testUser
+type testUser struct {
+	ID   int64
+	Name string
+}
+
+func TestTieredCacheZeroValuePolicyWithPointers(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		policy      ZeroValuePolicy
+		wantErr     error
+		wantCompute int // compute calls after two Gets
+	}{
+		{"ZeroValueCache", ZeroValueCache, nil, 1},
+		{"ZeroValueSkip", ZeroValueSkip, nil, 2},
+		{"ZeroValueNotFound", ZeroValueNotFound, ErrNotFound, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l2, _ := newTestRedisCache[*testUser](t, nil, nil)
+			config := DefaultTieredCacheConfig()
+			config.ZeroValuePolicy = tt.policy
+			config.NegativeTTL = time.Hour
+			tc := NewTieredCacheWithConfig[*testUser](config, NewMapCache[*testUser](), l2)
+
+			computes := 0
+			computeFn := func(ctx context.Context, key string) (*testUser, error) {
+				computes++
+				return nil, nil
+			}
+			for range 2 {
+				got, err := tc.Get(ctx, "user:1", time.Hour, computeFn)
+				if !errors.Is(err, tt.wantErr) || (err == nil && got != nil) {
+					t.Fatalf("Get = %v, %v; want nil, %v", got, err, tt.wantErr)
+				}
+			}
+			if computes != tt.wantCompute {
+				t.Errorf("compute calls = %d, want %d", computes, tt.wantCompute)
+			}
+		})
+	}
+}
+
+func TestTieredCacheCachesNonNilPointers(t *testing.T) {
+	ctx := context.Background()
+	l2, _ := newTestRedisCache[*testUser](t, nil, nil)
+	config := DefaultTieredCacheConfig()
+	config.ZeroValuePolicy = ZeroValueSkip
+	tc := NewTieredCacheWithConfig[*testUser](config, NewMapCache[*testUser](), l2)
+
+	want := &testUser{ID: 1, Name: "Jane Doe"}
+	if _, err := tc.Get(ctx, "user:1", time.Hour, func(ctx context.Context, key string) (*testUser, error) {
+		return want, nil
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := l2.Get(ctx, "user:1")
+	if err != nil || got == nil || *got != *want {
+		t.Errorf("L2 Get = %v, %v; want %v, nil", got, err, want)
+	}
+}
+
+func TestTieredCacheJitteredRefreshDoesNotBlockMisses(t *testing.T) {
+	ctx := context.Background()
+	// The tier and the refresh jitter use separate clocks, so the key can expire while the refresh waits
+	cacheClock, jitterClock := newFakeClock(), newFakeClock()
+	config := DefaultTieredCacheConfig()
+	config.RefreshAhead = 0.5
+	config.RefreshJitter = time.Hour
+	config.Clock = jitterClock
+	tc := NewTieredCacheWithConfig[string](config, NewMapCacheWithConfig[string](&MapCacheConfig{Clock: cacheClock}))
+	t.Cleanup(func() { jitterClock.Advance(time.Hour) })
+
+	var computes atomic.Int32
+	computeFn := func(ctx context.Context, key string) (string, error) {
+		return fmt.Sprintf("v%d", computes.Add(1)), nil
+	}
+	if got, err := tc.Get(ctx, "key", 10*time.Minute, computeFn); err != nil || got != "v1" {
+		t.Fatalf("Get = %q, %v; want %q, nil", got, err, "v1")
+	}
+
+	// Within the refresh-ahead window, Gets schedule a single jittered refresh
+	cacheClock.Advance(6 * time.Minute)
+	for range 2 {
+		if got, err := tc.Get(ctx, "key", 10*time.Minute, computeFn); err != nil || got != "v1" {
+			t.Fatalf("Get = %q, %v; want %q, nil", got, err, "v1")
+		}
+	}
+	jitterClock.WaitForWaiters(1)
+	if n := jitterClock.Waiters(); n != 1 {
+		t.Errorf("pending refreshes = %d, want 1", n)
+	}
+
+	// The key expires before the refresh starts; a miss computes at once instead of waiting for the jitter
+	cacheClock.Advance(5 * time.Minute)
+	if got, err := tc.Get(ctx, "key", 10*time.Minute, computeFn); err != nil || got != "v2" {
+		t.Fatalf("Get after expiry = %q, %v; want %q, nil", got, err, "v2")
+	}
+}
+
+// tenant is request metadata attached with WithMetadata in the tests
+type tenant string
+
+// probeSignalCache is a MapCache whose Get sends the tenant of the caller's ctx on probed
+type probeSignalCache struct {
+	*MapCache[string]
+	probed chan tenant
+}
+
+func (c *probeSignalCache) Get(ctx context.Context, key string) (string, error) {
+	id, _ := MetadataFrom[tenant](ctx)
+	c.probed <- id
+	return c.MapCache.Get(ctx, key)
+}
+
+func TestTieredCacheComputeSeesGetContext(t *testing.T) {
+	ctx := WithMetadata(context.Background(), tenant("a"))
+	tc := NewTieredCache[string](NewMapCache[string]())
+
+	got, err := tc.Get(ctx, "key", time.Hour, func(ctx context.Context, key string) (string, error) {
+		id, ok := MetadataFrom[tenant](ctx)
+		if !ok {
+			return "", errors.New("no tenant in compute ctx")
+		}
+		return "computed for " + string(id), nil
+	})
+	if err != nil || got != "computed for a" {
+		t.Errorf("Get = %q, %v; want %q, nil", got, err, "computed for a")
+	}
+}
+
+func TestTieredCacheCoalescingContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        CoalescingMode
+		wantB       string // value returned to the caller of tenant b
+		wantCompute int32
+	}{
+		// The caller of tenant b joins the compute started by tenant a and shares its ctx and result
+		{"CoalesceShared", CoalesceShared, "computed for a", 1},
+		// Each caller computes with its own ctx while the other compute is still running
+		{"CoalescePerCaller", CoalescePerCaller, "computed for b", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l1 := &probeSignalCache{MapCache: NewMapCache[string](), probed: make(chan tenant, 2)}
+			config := DefaultTieredCacheConfig()
+			config.Coalescing = tt.mode
+			tc := NewTieredCacheWithConfig[string](config, l1)
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			var computes atomic.Int32
+			computeFn := func(ctx context.Context, key string) (string, error) {
+				computes.Add(1)
+				id, _ := MetadataFrom[tenant](ctx)
+				if id == "a" {
+					close(started)
+					<-release
+				}
+				return "computed for " + string(id), nil
+			}
+
+			type result struct {
+				value string
+				err   error
+			}
+			get := func(id tenant) <-chan result {
+				ch := make(chan result, 1)
+				go func() {
+					value, err := tc.Get(WithMetadata(context.Background(), id), "key", time.Hour, computeFn)
+					ch <- result{value, err}
+				}()
+				return ch
+			}
+
+			resA := get("a")
+			<-started
+			resB := get("b")
+			// b missed every tier; yielding lets it reach the compute before a's compute returns
+			for <-l1.probed != "b" {
+			}
+			for range 100 {
+				runtime.Gosched()
+			}
+			close(release)
+
+			if r := <-resA; r.err != nil || r.value != "computed for a" {
+				t.Errorf("Get for a = %q, %v; want %q, nil", r.value, r.err, "computed for a")
+			}
+			if r := <-resB; r.err != nil || r.value != tt.wantB {
+				t.Errorf("Get for b = %q, %v; want %q, nil", r.value, r.err, tt.wantB)
+			}
+			if n := computes.Load(); n != tt.wantCompute {
+				t.Errorf("compute calls = %d, want %d", n, tt.wantCompute)
+			}
+		})
+	}
+}
+
+// BenchmarkTieredCacheSingleflightShards measures Get contention on the singleflight groups with many goroutines
+// computing distinct keys. The only tier never stores anything, so every Get goes through singleflight
+// Contention only shows on multiple cores, e.g., go test -bench SingleflightShards -cpu 1,8,32
+func BenchmarkTieredCacheSingleflightShards(b *testing.B) {
+	const numKeys = 1 << 16
+	keys := make([]string, numKeys)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+	computeFn := func(ctx context.Context, key string) (int, error) {
+		return len(key), nil
+	}
+
+	for _, shards := range []int{1, 4, 16, 64} {
+		b.Run(fmt.Sprintf("Shards=%d", shards), func(b *testing.B) {
+			config := DefaultTieredCacheConfig()
+			config.SingleflightShards = shards
+			tc := NewTieredCacheWithConfig[int](config, NewNoopCache[int]())
+			ctx := context.Background()
+			var next atomic.Uint64
+
+			b.SetParallelism(64)
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					key := keys[next.Add(1)%numKeys]
+					if _, err := tc.Get(ctx, key, time.Minute, computeFn); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// failingClearCache is a MapCache whose Clear always fails
+type failingClearCache struct {
+	*MapCache[string]
+}
+
+func (c *failingClearCache) Clear(ctx context.Context) error {
+	return errors.New("backend down")
+}
+
+func TestTieredCacheClearContinuesAfterTierError(t *testing.T) {
+	ctx := context.Background()
+	l2 := NewMapCache[string]()
+	tc := NewTieredCache[string](&failingClearCache{NewMapCache[string]()}, l2)
+	if err := l2.Set(ctx, "a", "1", time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	err := tc.Clear(ctx)
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 1 || !strings.HasPrefix(multi.Errors[0].Error(), "L1: ") {
+		t.Fatalf("Clear = %v, want a *MultiError naming L1", err)
+	}
+	if _, err := l2.Get(ctx, "a"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("L2 Get after Clear = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestTieredCacheComputeTimeoutUsesClock(t *testing.T) {
+	ctx := context.Background()
+	clock := newFakeClock()
+	config := DefaultTieredCacheConfig()
+	config.ComputeTimeout = time.Second
+	config.Clock = clock
+	tc := NewTieredCacheWithConfig[string](config, NewMapCache[string]())
+
+	release := make(chan struct{})
+	defer close(release)
+	go func() {
+		clock.WaitForWaiters(1)
+		clock.Advance(time.Second)
+	}()
+
+	_, err := tc.Get(ctx, "key", time.Hour, func(ctx context.Context, key string) (string, error) {
+		<-release
+		return "v", nil
+	})
+	if !errors.Is(err, ErrComputeFailed) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get = %v, want ErrComputeFailed wrapping context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// TimeoutCacher wraps a Cacher and bounds every operation with a timeout
+// It is a safety net for call sites passing context.Background(): each operation runs with a context
+// derived from the caller's one, so an earlier caller deadline still applies and cancellation still propagates.
+// An operation that times out returns an error wrapping context.DeadlineExceeded from the wrapped cache
+type TimeoutCacher[V any] struct {
+	inner        Cacher[V]
+	timeout      time.Duration
+	batchTimeout time.Duration
+}
+
+// TimeoutConfig holds configuration for TimeoutCacher
+type TimeoutConfig struct {
+	// Timeout bounds each Get, Set, Delete, SetNotFound, GetTTL and Ping (0 = no timeout).
+	Timeout time.Duration
+
+	// BatchTimeout bounds each batch operation (0 = use Timeout).
+	// Batches usually touch more keys and may need a longer bound than single-key operations.
+	BatchTimeout time.Duration
+}
+
+// DefaultTimeoutConfig returns a default configuration
+func DefaultTimeoutConfig() *TimeoutConfig {
+	return &TimeoutConfig{
+		Timeout:      time.Second,
+		BatchTimeout: 0, // same as Timeout
+	}
+}
+
+// NewTimeoutCacher creates a new TimeoutCacher wrapping the given cache
+func NewTimeoutCacher[V any](inner Cacher[V], config *TimeoutConfig) *TimeoutCacher[V] {
+	if config == nil {
+		config = DefaultTimeoutConfig()
+	}
+	batchTimeout := config.BatchTimeout
+	if batchTimeout <= 0 {
+		batchTimeout = config.Timeout
+	}
+	return &TimeoutCacher[V]{
+		inner:        inner,
+		timeout:      config.Timeout,
+		batchTimeout: batchTimeout,
+	}
+}
+
+// Get retrieves a value from the wrapped cache within the timeout
+func (t *TimeoutCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	ctx, cancel := withTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.inner.Get(ctx, key)
+}
+
+// Set stores a value in the wrapped cache within the timeout
+func (t *TimeoutCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	ctx, cancel := withTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.inner.Set(ctx, key, value, ttl)
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache within the timeout
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (t *TimeoutCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	ctx, cancel := withTimeout(ctx, t.timeout)
+	defer cancel()
+	return forwardSetNotFound(ctx, t.inner, key, ttl)
+}
+
+// Delete removes a value from the wrapped cache within the timeout
+func (t *TimeoutCacher[V]) Delete(ctx context.Context, key string) error {
+	ctx, cancel := withTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.inner.Delete(ctx, key)
+}
+
+// BatchGet retrieves multiple values from the wrapped cache within the batch timeout
+func (t *TimeoutCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	ctx, cancel := withTimeout(ctx, t.batchTimeout)
+	defer cancel()
+	return batchGet(ctx, t.inner, keys)
+}
+
+// BatchSet stores multiple values in the wrapped cache within the batch timeout
+func (t *TimeoutCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	ctx, cancel := withTimeout(ctx, t.batchTimeout)
+	defer cancel()
+	return batchSet(ctx, t.inner, items, ttl)
+}
+
+// BatchDelete removes multiple values from the wrapped cache within the batch timeout
+func (t *TimeoutCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	ctx, cancel := withTimeout(ctx, t.batchTimeout)
+	defer cancel()
+	return batchDelete(ctx, t.inner, keys)
+}
+
+// GetTTL returns the remaining time-to-live of a key in the wrapped cache within the timeout
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (t *TimeoutCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	ctx, cancel := withTimeout(ctx, t.timeout)
+	defer cancel()
+	return forwardGetTTL(ctx, t.inner, key)
+}
+
+// Ping checks the wrapped cache's backend within the timeout
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (t *TimeoutCacher[V]) Ping(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, t.timeout)
+	defer cancel()
+	return forwardPing(ctx, t.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (t *TimeoutCacher[V]) Close() error {
+	return forwardClose(t.inner)
+}
+
+// withTimeout derives a context with the timeout, or returns ctx unchanged if timeout is zero or less
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTimeoutCacherBoundsGetTTLAndPing(t *testing.T) {
+	ctx := context.Background()
+	spy := &deadlineSpy{lifecycleSpy: lifecycleSpy{MapCache: NewMapCache[string]()}}
+	tc := NewTimeoutCacher[string](spy, &TimeoutConfig{Timeout: time.Minute})
+
+	if _, err := tc.GetTTL(ctx, "key"); err != nil {
+		t.Fatalf("GetTTL: %v", err)
+	}
+	if err := tc.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if !spy.ttlDeadline || !spy.pingDeadline {
+		t.Errorf("deadline seen by GetTTL = %t, Ping = %t; want both true", spy.ttlDeadline, spy.pingDeadline)
+	}
+}
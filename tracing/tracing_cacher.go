@@ -0,0 +1,205 @@
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	cache "github.com/naoto0822/exp-go-cache"
+)
+
+const (
+	operationGet         = "get"
+	operationSet         = "set"
+	operationDelete      = "delete"
+	operationBatchGet    = "batch_get"
+	operationBatchSet    = "batch_set"
+	operationBatchDelete = "batch_delete"
+)
+
+// TracingCacher wraps a Cacher and starts an OpenTelemetry span for every operation
+// Span attributes:
+//   - cache.name: the configured cache name
+//   - cache.operation: get/set/delete/batch_get/batch_set/batch_delete
+//   - cache.key: the key (or its SHA-256 hash) for single-key operations
+//   - cache.hit: whether Get found the key (ErrCacheMiss is not recorded as an error)
+//   - cache.batch_size and cache.hits: the number of keys and found keys for batch operations
+type TracingCacher[V any] struct {
+	inner    cache.Cacher[V]
+	tracer   trace.Tracer
+	name     string
+	hashKeys bool
+}
+
+// TracingConfig holds configuration for TracingCacher
+type TracingConfig struct {
+	// Name is the value of the cache.name attribute, used to tell wrapped caches apart (e.g., "redis")
+	Name string
+
+	// HashKeys records the SHA-256 hash of keys instead of the raw keys, for privacy
+	HashKeys bool
+}
+
+// DefaultTracingConfig returns a default configuration
+func DefaultTracingConfig() *TracingConfig {
+	return &TracingConfig{
+		Name:     "default",
+		HashKeys: false,
+	}
+}
+
+// NewTracingCacher creates a new TracingCacher wrapping the given cache
+func NewTracingCacher[V any](inner cache.Cacher[V], tracer trace.Tracer, config *TracingConfig) *TracingCacher[V] {
+	if config == nil {
+		config = DefaultTracingConfig()
+	}
+	return &TracingCacher[V]{
+		inner:    inner,
+		tracer:   tracer,
+		name:     config.Name,
+		hashKeys: config.HashKeys,
+	}
+}
+
+// Get retrieves a value from the wrapped cache within a span
+func (t *TracingCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	ctx, span := t.start(ctx, operationGet, attribute.String("cache.key", t.key(key)))
+	defer span.End()
+
+	value, err := t.inner.Get(ctx, key)
+	span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+	if !errors.Is(err, cache.ErrCacheMiss) {
+		recordError(span, err)
+	}
+	return value, err
+}
+
+// Set stores a value in the wrapped cache within a span
+func (t *TracingCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	ctx, span := t.start(ctx, operationSet, attribute.String("cache.key", t.key(key)))
+	defer span.End()
+
+	err := t.inner.Set(ctx, key, value, ttl)
+	recordError(span, err)
+	return err
+}
+
+// Delete removes a value from the wrapped cache within a span
+func (t *TracingCacher[V]) Delete(ctx context.Context, key string) error {
+	ctx, span := t.start(ctx, operationDelete, attribute.String("cache.key", t.key(key)))
+	defer span.End()
+
+	err := t.inner.Delete(ctx, key)
+	if !errors.Is(err, cache.ErrCacheMiss) {
+		recordError(span, err)
+	}
+	return err
+}
+
+// BatchGet retrieves multiple values from the wrapped cache within a span
+func (t *TracingCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	ctx, span := t.start(ctx, operationBatchGet, attribute.Int("cache.batch_size", len(keys)))
+	defer span.End()
+
+	var results map[string]V
+	var err error
+	if bc, ok := t.inner.(cache.BatchCacher[V]); ok {
+		results, err = bc.BatchGet(ctx, keys)
+	} else {
+		results, err = t.getEach(ctx, keys)
+	}
+	span.SetAttributes(attribute.Int("cache.hits", len(results)))
+	recordError(span, err)
+	return results, err
+}
+
+// BatchSet stores multiple values in the wrapped cache within a span
+func (t *TracingCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	ctx, span := t.start(ctx, operationBatchSet, attribute.Int("cache.batch_size", len(items)))
+	defer span.End()
+
+	var err error
+	if bc, ok := t.inner.(cache.BatchCacher[V]); ok {
+		err = bc.BatchSet(ctx, items, ttl)
+	} else {
+		for key, value := range items {
+			if err = t.inner.Set(ctx, key, value, ttl); err != nil {
+				break
+			}
+		}
+	}
+	recordError(span, err)
+	return err
+}
+
+// BatchDelete removes multiple values from the wrapped cache within a span
+func (t *TracingCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	ctx, span := t.start(ctx, operationBatchDelete, attribute.Int("cache.batch_size", len(keys)))
+	defer span.End()
+
+	var err error
+	if bc, ok := t.inner.(cache.BatchCacher[V]); ok {
+		err = bc.BatchDelete(ctx, keys)
+	} else {
+		for _, key := range keys {
+			if err = t.inner.Delete(ctx, key); err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+				break
+			}
+			err = nil
+		}
+	}
+	recordError(span, err)
+	return err
+}
+
+// getEach retrieves multiple values with sequential Get calls for caches without batch support
+func (t *TracingCacher[V]) getEach(ctx context.Context, keys []string) (map[string]V, error) {
+	results := make(map[string]V, len(keys))
+	for _, key := range keys {
+		value, err := t.inner.Get(ctx, key)
+		if err != nil {
+			if errors.Is(err, cache.ErrCacheMiss) {
+				continue
+			}
+			return results, err
+		}
+		results[key] = value
+	}
+	return results, nil
+}
+
+// start starts a span for an operation with the common attributes
+func (t *TracingCacher[V]) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("cache.name", t.name),
+		attribute.String("cache.operation", operation),
+	)
+	return t.tracer.Start(ctx, "cache."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// key returns the key as recorded on spans
+func (t *TracingCacher[V]) key(key string) string {
+	if !t.hashKeys {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// recordError records a non-nil error on the span and marks the span as failed
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// TTLClampCacher wraps a Cacher and clamps every TTL it writes into [MinTTL, MaxTTL]
+// It guards any backend against bogus TTLs, e.g., time.Duration(math.MaxInt64) making entries effectively
+// permanent, or sub-second TTLs causing churn
+// To clamp the TTLs of a TieredCache, wrap each tier before passing it to NewTieredCache
+type TTLClampCacher[V any] struct {
+	inner         Cacher[V]
+	minTTL        time.Duration
+	maxTTL        time.Duration
+	clampNoExpiry bool
+	logger        *slog.Logger
+}
+
+// TTLClampConfig holds configuration for TTLClampCacher
+type TTLClampConfig struct {
+	// MinTTL is the minimum TTL; positive TTLs below it are raised to it (0 = no minimum)
+	MinTTL time.Duration
+
+	// MaxTTL is the maximum TTL; TTLs above it are lowered to it (0 = no maximum)
+	MaxTTL time.Duration
+
+	// ClampNoExpiry also applies MaxTTL to TTLs of zero or less, which otherwise mean no expiry.
+	// Only used when MaxTTL is positive.
+	ClampNoExpiry bool
+
+	// Logger logs every clamped TTL with the key and the original TTL (optional)
+	Logger *slog.Logger
+}
+
+// DefaultTTLClampConfig returns a default configuration
+func DefaultTTLClampConfig() *TTLClampConfig {
+	return &TTLClampConfig{
+		MinTTL:        0, // no minimum
+		MaxTTL:        0, // no maximum
+		ClampNoExpiry: false,
+		Logger:        nil,
+	}
+}
+
+// NewTTLClampCacher creates a new TTLClampCacher wrapping the given cache
+func NewTTLClampCacher[V any](inner Cacher[V], config *TTLClampConfig) *TTLClampCacher[V] {
+	if config == nil {
+		config = DefaultTTLClampConfig()
+	}
+	return &TTLClampCacher[V]{
+		inner:         inner,
+		minTTL:        config.MinTTL,
+		maxTTL:        config.MaxTTL,
+		clampNoExpiry: config.ClampNoExpiry,
+		logger:        config.Logger,
+	}
+}
+
+// Get retrieves a value from the wrapped cache
+func (t *TTLClampCacher[V]) Get(ctx context.Context, key string) (V, error) {
+	return t.inner.Get(ctx, key)
+}
+
+// Set stores a value in the wrapped cache with a clamped TTL
+func (t *TTLClampCacher[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return t.inner.Set(ctx, key, value, t.clamp(ctx, key, ttl))
+}
+
+// SetNotFound stores a negative cache entry in the wrapped cache with a clamped TTL
+// Returns ErrUnsupported if the wrapped cache does not implement NegativeCacher
+func (t *TTLClampCacher[V]) SetNotFound(ctx context.Context, key string, ttl time.Duration) error {
+	return forwardSetNotFound(ctx, t.inner, key, t.clamp(ctx, key, ttl))
+}
+
+// Delete removes a value from the wrapped cache
+func (t *TTLClampCacher[V]) Delete(ctx context.Context, key string) error {
+	return t.inner.Delete(ctx, key)
+}
+
+// BatchGet retrieves multiple values from the wrapped cache
+func (t *TTLClampCacher[V]) BatchGet(ctx context.Context, keys []string) (map[string]V, error) {
+	return batchGet(ctx, t.inner, keys)
+}
+
+// BatchSet stores multiple values in the wrapped cache with a clamped TTL
+// The shared TTL is clamped once and logged at most once per call
+func (t *TTLClampCacher[V]) BatchSet(ctx context.Context, items map[string]V, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return batchSet(ctx, t.inner, items, t.clamp(ctx, "", ttl))
+}
+
+// BatchDelete removes multiple values from the wrapped cache
+func (t *TTLClampCacher[V]) BatchDelete(ctx context.Context, keys []string) error {
+	return batchDelete(ctx, t.inner, keys)
+}
+
+// GetTTL returns the remaining time-to-live of a key in the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement TTLer
+func (t *TTLClampCacher[V]) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	return forwardGetTTL(ctx, t.inner, key)
+}
+
+// Ping checks the wrapped cache's backend
+// Returns ErrUnsupported if the wrapped cache does not implement Pinger
+func (t *TTLClampCacher[V]) Ping(ctx context.Context) error {
+	return forwardPing(ctx, t.inner)
+}
+
+// Close closes the wrapped cache
+// Returns ErrUnsupported if the wrapped cache does not implement io.Closer
+func (t *TTLClampCacher[V]) Close() error {
+	return forwardClose(t.inner)
+}
+
+// clamp returns ttl clamped into [MinTTL, MaxTTL] and logs the change if a Logger is configured
+// key is only used for logging and is empty for batch writes
+func (t *TTLClampCacher[V]) clamp(ctx context.Context, key string, ttl time.Duration) time.Duration {
+	clamped := ttl
+	switch {
+	case ttl <= 0:
+		if t.clampNoExpiry && t.maxTTL > 0 {
+			clamped = t.maxTTL
+		}
+	case t.maxTTL > 0 && ttl > t.maxTTL:
+		clamped = t.maxTTL
+	case t.minTTL > 0 && ttl < t.minTTL:
+		clamped = t.minTTL
+	}
+	if clamped != ttl && t.logger != nil {
+		t.logger.WarnContext(ctx, "cache: clamping TTL",
+			slog.String("key", key), slog.Duration("ttl", ttl), slog.Duration("clamped", clamped))
+	}
+	return clamped
+}
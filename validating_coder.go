@@ -0,0 +1,70 @@
+package cache
+
+// ValidateFunc checks a value and returns an error if it is not valid
+type ValidateFunc[V any] func(value V) error
+
+// ValidatingCoder implements Coder by running a ValidateFunc on the values decoded by an inner Coder
+// It rejects values that decode without error but are semantically invalid (e.g., an empty JSON object
+// decoding into a zero struct), so corrupt entries are treated as errors instead of being served
+// Validation errors satisfy errors.Is(err, ErrInvalidValue) and are available via errors.Unwrap
+type ValidatingCoder[V any] struct {
+	inner            Coder[V]
+	validate         ValidateFunc[V]
+	validateOnEncode bool
+}
+
+// ValidatingCoderConfig holds configuration for ValidatingCoder
+type ValidatingCoderConfig struct {
+	// ValidateOnEncode also validates values before encoding them,
+	// so invalid values are never written to the cache
+	ValidateOnEncode bool
+}
+
+// DefaultValidatingCoderConfig returns a default configuration
+func DefaultValidatingCoderConfig() *ValidatingCoderConfig {
+	return &ValidatingCoderConfig{
+		ValidateOnEncode: false,
+	}
+}
+
+// NewValidatingCoder creates a new ValidatingCoder wrapping the given Coder
+// If inner is nil, JSONCoder is used. If validate is nil, every value is valid
+func NewValidatingCoder[V any](inner Coder[V], validate ValidateFunc[V], config *ValidatingCoderConfig) *ValidatingCoder[V] {
+	if config == nil {
+		config = DefaultValidatingCoderConfig()
+	}
+	if inner == nil {
+		inner = NewJSONCoder[V]()
+	}
+	if validate == nil {
+		validate = func(V) error { return nil }
+	}
+	return &ValidatingCoder[V]{
+		inner:            inner,
+		validate:         validate,
+		validateOnEncode: config.ValidateOnEncode,
+	}
+}
+
+// Encode serializes a value with the inner Coder, validating it first if ValidateOnEncode is enabled
+func (c *ValidatingCoder[V]) Encode(value V) ([]byte, error) {
+	if c.validateOnEncode {
+		if err := c.validate(value); err != nil {
+			return nil, &kindError{kind: ErrInvalidValue, err: err}
+		}
+	}
+	return c.inner.Encode(value)
+}
+
+// Decode deserializes data with the inner Coder and validates the result
+func (c *ValidatingCoder[V]) Decode(data []byte) (V, error) {
+	value, err := c.inner.Decode(data)
+	if err != nil {
+		return value, err
+	}
+	if err := c.validate(value); err != nil {
+		var zero V
+		return zero, &kindError{kind: ErrInvalidValue, err: err}
+	}
+	return value, nil
+}
@@ -0,0 +1,229 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// WriteBackTieredCache implements a tiered cache with write-back (write-behind) semantics
+// Set writes caches[0] (L1) synchronously and queues the write for the lower tiers (L2, ..., Ln),
+// which a background worker flushes with BatchSet periodically or when the buffer fills up.
+// Pending writes to the same key are coalesced, and the last one wins.
+//
+// Durability tradeoff: lower tiers lag L1 by up to the flush interval, other instances reading
+// lower tiers may see old values in the meantime, and queued writes are lost if the process
+// crashes or a flush fails. TTLs of queued writes start counting when they are flushed.
+// Values computed by Get are written to all tiers synchronously
+type WriteBackTieredCache[V any] struct {
+	tiered        *TieredCache[V]
+	upper         Cacher[V]
+	lower         []Cacher[V]
+	flushInterval time.Duration
+	flushJitter   time.Duration
+	bufferSize    int
+	onFlushError  func(err error)
+
+	mu      sync.Mutex
+	pending map[string]writeBackItem[V]
+
+	flushMu   sync.Mutex
+	notify    chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// writeBackItem is a queued write for the lower tiers
+type writeBackItem[V any] struct {
+	value V
+	ttl   time.Duration
+}
+
+// WriteBackTieredCacheConfig holds configuration for WriteBackTieredCache
+type WriteBackTieredCacheConfig struct {
+	// FlushInterval is how often queued writes are flushed to the lower tiers
+	FlushInterval time.Duration
+
+	// FlushJitter adds a random duration in [0, FlushJitter) to each flush interval when positive,
+	// so instances started together do not flush to the lower tiers in lockstep
+	FlushJitter time.Duration
+
+	// BufferSize is the number of queued writes that triggers an early flush
+	BufferSize int
+
+	// OnFlushError is called when a background flush fails (optional)
+	// The writes that failed are dropped
+	OnFlushError func(err error)
+
+	// Tiered is the configuration for the underlying TieredCache (optional)
+	Tiered *TieredCacheConfig
+}
+
+// DefaultWriteBackTieredCacheConfig returns a default configuration
+func DefaultWriteBackTieredCacheConfig() *WriteBackTieredCacheConfig {
+	return &WriteBackTieredCacheConfig{
+		FlushInterval: time.Second,
+		FlushJitter:   0, // no jitter
+		BufferSize:    1000,
+		OnFlushError:  nil,
+		Tiered:        nil,
+	}
+}
+
+// NewWriteBackTieredCache creates a new WriteBackTieredCache and starts the flush worker in a background goroutine
+// caches is a slice where caches[0] is L1 (fastest), caches[1] is L2, etc.
+func NewWriteBackTieredCache[V any](config *WriteBackTieredCacheConfig, caches ...Cacher[V]) *WriteBackTieredCache[V] {
+	if config == nil {
+		config = DefaultWriteBackTieredCacheConfig()
+	}
+	tiered := NewTieredCacheWithConfig(config.Tiered, caches...)
+
+	flushInterval := config.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+
+	wb := &WriteBackTieredCache[V]{
+		tiered:        tiered,
+		flushInterval: flushInterval,
+		flushJitter:   config.FlushJitter,
+		bufferSize:    config.BufferSize,
+		onFlushError:  config.OnFlushError,
+		pending:       make(map[string]writeBackItem[V]),
+		notify:        make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	if len(tiered.caches) > 0 {
+		wb.upper = tiered.caches[0]
+		wb.lower = tiered.caches[1:]
+	}
+	go wb.run()
+	return wb
+}
+
+// Get retrieves a value using the tiered caching strategy with compute function
+// A value whose write is still queued is served from L1
+func (wb *WriteBackTieredCache[V]) Get(ctx context.Context, key string, ttl time.Duration, computeFn ComputeFunc[V]) (V, error) {
+	return wb.tiered.Get(ctx, key, ttl, computeFn)
+}
+
+// Set stores a value in L1 and queues the write for the lower tiers
+func (wb *WriteBackTieredCache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	if wb.upper == nil {
+		return nil
+	}
+	if err := wb.upper.Set(ctx, key, value, ttl); err != nil && !errors.Is(err, ErrSetDropped) {
+		return err
+	}
+	if len(wb.lower) == 0 {
+		return nil
+	}
+
+	wb.mu.Lock()
+	wb.pending[key] = writeBackItem[V]{value: value, ttl: ttl}
+	full := wb.bufferSize > 0 && len(wb.pending) >= wb.bufferSize
+	wb.mu.Unlock()
+
+	if full {
+		select {
+		case wb.notify <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Delete discards any queued write for the key and removes it from all cache tiers
+// It waits for a flush in progress, so the flush cannot write the deleted value back to the lower tiers
+func (wb *WriteBackTieredCache[V]) Delete(ctx context.Context, key string) error {
+	wb.flushMu.Lock()
+	defer wb.flushMu.Unlock()
+
+	wb.mu.Lock()
+	delete(wb.pending, key)
+	wb.mu.Unlock()
+
+	return wb.tiered.Delete(ctx, key)
+}
+
+// Flush writes all queued writes to the lower tiers
+// Every TTL group is written to every lower tier even if some writes fail, and the failures are returned
+// as a *MultiError naming each failed tier (e.g., "L2: ..."). The queued writes are not retried
+func (wb *WriteBackTieredCache[V]) Flush(ctx context.Context) error {
+	wb.flushMu.Lock()
+	defer wb.flushMu.Unlock()
+
+	wb.mu.Lock()
+	pending := wb.pending
+	wb.pending = make(map[string]writeBackItem[V])
+	wb.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Group by TTL since BatchSet shares one TTL across items
+	groups := make(map[time.Duration]map[string]V)
+	for key, item := range pending {
+		if groups[item.ttl] == nil {
+			groups[item.ttl] = make(map[string]V)
+		}
+		groups[item.ttl][key] = item.value
+	}
+
+	var errs []error
+	for ttl, items := range groups {
+		for i, cache := range wb.lower {
+			if err := batchSet(ctx, cache, items, ttl); err != nil && !errors.Is(err, ErrSetDropped) {
+				errs = append(errs, tierError(i+1, err))
+			}
+		}
+	}
+	return newMultiError(errs...)
+}
+
+// Close stops the flush worker, flushes the remaining queued writes, and closes all cache tiers
+// like TieredCache.Close. The tiers are closed even if the final flush fails
+func (wb *WriteBackTieredCache[V]) Close() error {
+	wb.closeOnce.Do(func() {
+		close(wb.stop)
+		<-wb.done
+		wb.closeErr = wb.Flush(context.Background())
+		if tiersErr := wb.tiered.Close(); tiersErr != nil {
+			wb.closeErr = newMultiError(wb.closeErr, tiersErr)
+		}
+	})
+	return wb.closeErr
+}
+
+// nextFlushInterval returns the flush interval plus a random jitter of up to FlushJitter
+func (wb *WriteBackTieredCache[V]) nextFlushInterval() time.Duration {
+	if wb.flushJitter <= 0 {
+		return wb.flushInterval
+	}
+	return wb.flushInterval + rand.N(wb.flushJitter)
+}
+
+// run flushes queued writes periodically or when notified until Close is called
+func (wb *WriteBackTieredCache[V]) run() {
+	defer close(wb.done)
+
+	next := wb.tiered.clock.After(wb.nextFlushInterval())
+	for {
+		select {
+		case <-wb.stop:
+			return
+		case <-next:
+		case <-wb.notify:
+		}
+		next = wb.tiered.clock.After(wb.nextFlushInterval())
+		if err := wb.Flush(context.Background()); err != nil && wb.onFlushError != nil {
+			wb.onFlushError(err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestWriteBackTieredCache creates a WriteBackTieredCache that only flushes when Flush is called
+// and is closed when the test ends
+func newTestWriteBackTieredCache(t *testing.T, caches ...Cacher[string]) *WriteBackTieredCache[string] {
+	t.Helper()
+	wb := NewWriteBackTieredCache(&WriteBackTieredCacheConfig{FlushInterval: time.Hour}, caches...)
+	t.Cleanup(func() { _ = wb.Close() })
+	return wb
+}
+
+// failingBatchCache is a MapCache whose BatchSet always fails
+type failingBatchCache struct {
+	*MapCache[string]
+}
+
+func (c *failingBatchCache) BatchSet(ctx context.Context, items map[string]string, ttl time.Duration) error {
+	return errors.New("backend down")
+}
+
+// blockingBatchCache is a MapCache whose BatchSet signals started and waits for release before writing
+type blockingBatchCache struct {
+	*MapCache[string]
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingBatchCache) BatchSet(ctx context.Context, items map[string]string, ttl time.Duration) error {
+	close(c.started)
+	<-c.release
+	return c.MapCache.BatchSet(ctx, items, ttl)
+}
+
+func TestWriteBackTieredCacheFlushWritesEveryTierAndGroup(t *testing.T) {
+	ctx := context.Background()
+	l3 := NewMapCache[string]()
+	wb := newTestWriteBackTieredCache(t, NewMapCache[string](), &failingBatchCache{NewMapCache[string]()}, l3)
+
+	if err := wb.Set(ctx, "a", "1", time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := wb.Set(ctx, "b", "2", time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	err := wb.Flush(ctx)
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("Flush = %v, want a *MultiError with one error per TTL group", err)
+	}
+	for _, err := range multi.Errors {
+		if !strings.HasPrefix(err.Error(), "L2: ") {
+			t.Errorf("Flush error %q does not name L2", err)
+		}
+	}
+	for key, want := range map[string]string{"a": "1", "b": "2"} {
+		if got, err := l3.Get(ctx, key); err != nil || got != want {
+			t.Errorf("L3 Get(%q) = %q, %v; want %q, nil", key, got, err, want)
+		}
+	}
+}
+
+func TestWriteBackTieredCacheDeleteDuringFlush(t *testing.T) {
+	ctx := context.Background()
+	l2 := &blockingBatchCache{MapCache: NewMapCache[string](), started: make(chan struct{}), release: make(chan struct{})}
+	wb := newTestWriteBackTieredCache(t, NewMapCache[string](), l2)
+
+	if err := wb.Set(ctx, "key", "old", time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	flushed := make(chan error, 1)
+	go func() { flushed <- wb.Flush(ctx) }()
+	<-l2.started
+
+	// The flush is writing "old" to L2; the delete must not be overtaken by it.
+	// Yielding lets a Delete that does not wait for the flush run to completion before the flush resumes
+	deleted := make(chan error, 1)
+	go func() { deleted <- wb.Delete(ctx, "key") }()
+	for range 100 {
+		runtime.Gosched()
+	}
+	close(l2.release)
+
+	if err := <-flushed; err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if err := <-deleted; err != nil && !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got, err := l2.Get(ctx, "key"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("L2 Get after Delete = %q, %v; want ErrCacheMiss", got, err)
+	}
+}
@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"bytes"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdMagic prefixes Zstandard-compressed payloads so Decode can tell them apart from uncompressed data
+var zstdMagic = []byte{0xff, 'Z'}
+
+// ZstdCoder implements Coder by compressing the output of an inner Coder with Zstandard
+// Zstandard offers a better ratio-to-speed tradeoff than gzip for text payloads such as JSON
+// Payloads without the Zstandard marker are passed to the inner Coder as-is, so uncompressed legacy data still decodes
+type ZstdCoder[V any] struct {
+	inner     Coder[V]
+	transform *ZstdTransform
+}
+
+// ZstdConfig holds configuration for ZstdCoder and ZstdTransform
+type ZstdConfig struct {
+	// Level is the zstd compression level (1 = fastest, 22 = best compression).
+	// Levels are mapped to the closest level supported by the encoder.
+	Level int
+
+	// MinCompressBytes is the size below which data is stored uncompressed, with a marker telling
+	// Decode it is not compressed (0 = compress everything).
+	// Compressing tiny values costs CPU and can even grow them.
+	MinCompressBytes int
+}
+
+// DefaultZstdConfig returns a default configuration
+func DefaultZstdConfig() *ZstdConfig {
+	return &ZstdConfig{
+		Level:            3,
+		MinCompressBytes: 0, // compress everything
+	}
+}
+
+// NewZstdCoder creates a new ZstdCoder instance wrapping the given Coder
+// If inner is nil, JSONCoder is used
+func NewZstdCoder[V any](inner Coder[V]) *ZstdCoder[V] {
+	if inner == nil {
+		inner = NewJSONCoder[V]()
+	}
+	return &ZstdCoder[V]{
+		inner:     inner,
+		transform: NewZstdTransform(),
+	}
+}
+
+// NewZstdCoderWithConfig creates a new ZstdCoder instance wrapping the given Coder with the given configuration
+// If inner is nil, JSONCoder is used. If config is nil, DefaultZstdConfig is used
+// Returns an error if the zstd encoder or decoder cannot be created with the configuration
+func NewZstdCoderWithConfig[V any](inner Coder[V], config *ZstdConfig) (*ZstdCoder[V], error) {
+	if inner == nil {
+		inner = NewJSONCoder[V]()
+	}
+	transform, err := NewZstdTransformWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ZstdCoder[V]{
+		inner:     inner,
+		transform: transform,
+	}, nil
+}
+
+// Encode serializes a value with the inner Coder and compresses it with Zstandard
+func (c *ZstdCoder[V]) Encode(value V) ([]byte, error) {
+	data, err := c.inner.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	return c.transform.Transform(data)
+}
+
+// Decode decompresses Zstandard bytes and deserializes them with the inner Coder
+func (c *ZstdCoder[V]) Decode(data []byte) (V, error) {
+	decoded, err := c.transform.Invert(data)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return c.inner.Decode(decoded)
+}
+
+// ZstdTransform implements ByteTransform using Zstandard compression
+// The encoder and decoder are created once and reused; both are safe for concurrent use
+// It can be combined with other transforms in a ChainCoder
+type ZstdTransform struct {
+	encoder          *zstd.Encoder
+	decoder          *zstd.Decoder
+	minCompressBytes int
+}
+
+// NewZstdTransform creates a new ZstdTransform instance
+func NewZstdTransform() *ZstdTransform {
+	t, err := NewZstdTransformWithConfig(nil)
+	if err != nil {
+		// Unreachable: zstd only rejects invalid options, and the default configuration has none
+		panic(err)
+	}
+	return t
+}
+
+// NewZstdTransformWithConfig creates a new ZstdTransform instance with the given configuration
+// If config is nil, DefaultZstdConfig is used
+// Returns an error if the zstd encoder or decoder cannot be created with the configuration
+func NewZstdTransformWithConfig(config *ZstdConfig) (*ZstdTransform, error) {
+	if config == nil {
+		config = DefaultZstdConfig()
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(config.Level)))
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ZstdTransform{
+		encoder:          encoder,
+		decoder:          decoder,
+		minCompressBytes: config.MinCompressBytes,
+	}, nil
+}
+
+// Transform compresses data with Zstandard and prefixes it with the Zstandard marker
+// Data shorter than MinCompressBytes is prefixed with the uncompressed marker instead
+func (t *ZstdTransform) Transform(data []byte) ([]byte, error) {
+	if len(data) < t.minCompressBytes {
+		return markUncompressed(data), nil
+	}
+	out := make([]byte, len(zstdMagic), len(zstdMagic)+len(data))
+	copy(out, zstdMagic)
+	return t.encoder.EncodeAll(data, out), nil
+}
+
+// Invert decompresses data with Zstandard
+// Data with the uncompressed marker is returned without it, and other data without the Zstandard marker is returned unchanged
+func (t *ZstdTransform) Invert(data []byte) ([]byte, error) {
+	if bytes.HasPrefix(data, uncompressedMagic) {
+		return data[len(uncompressedMagic):], nil
+	}
+	if !bytes.HasPrefix(data, zstdMagic) {
+		return data, nil
+	}
+	return t.decoder.DecodeAll(data[len(zstdMagic):], nil)
+}